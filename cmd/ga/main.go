@@ -32,9 +32,18 @@ func (c *OneMaxChromosome) Fitness() float64 {
 
 // Crossover creates a new chromosome by combining the genes of two parents.
 func (c *OneMaxChromosome) Crossover(other ga.Chromosome) ga.Chromosome {
+	return c.crossover(other, rand.Intn)
+}
+
+// CrossoverRand is like Crossover but draws the crossover point from rng.
+func (c *OneMaxChromosome) CrossoverRand(other ga.Chromosome, rng *rand.Rand) ga.Chromosome {
+	return c.crossover(other, rng.Intn)
+}
+
+func (c *OneMaxChromosome) crossover(other ga.Chromosome, intn func(int) int) ga.Chromosome {
 	parent1 := c.Genes
 	parent2 := other.(*OneMaxChromosome).Genes
-	crossoverPoint := rand.Intn(len(parent1))
+	crossoverPoint := intn(len(parent1))
 	childGenes := make([]bool, len(parent1))
 	copy(childGenes[:crossoverPoint], parent1[:crossoverPoint])
 	copy(childGenes[crossoverPoint:], parent2[crossoverPoint:])
@@ -43,7 +52,16 @@ func (c *OneMaxChromosome) Crossover(other ga.Chromosome) ga.Chromosome {
 
 // Mutate randomly flips a gene in the chromosome.
 func (c *OneMaxChromosome) Mutate() {
-	mutationPoint := rand.Intn(len(c.Genes))
+	c.mutate(rand.Intn)
+}
+
+// MutateRand is like Mutate but draws the mutation point from rng.
+func (c *OneMaxChromosome) MutateRand(rng *rand.Rand) {
+	c.mutate(rng.Intn)
+}
+
+func (c *OneMaxChromosome) mutate(intn func(int) int) {
+	mutationPoint := intn(len(c.Genes))
 	c.Genes[mutationPoint] = !c.Genes[mutationPoint]
 }
 
@@ -64,19 +82,22 @@ func main() {
 }
 
 func runOneMax() {
-	// Create an initial population.
-	population := make([]ga.Chromosome, 100)
-	for i := range population {
-		genes := make([]bool, 20)
-		for j := range genes {
-			genes[j] = rand.Float64() < 0.5
+	// Create random bit strings for the initial population.
+	bitStrings := ga.InitializerFunc(func(size int, rng *rand.Rand) []ga.Chromosome {
+		population := make([]ga.Chromosome, size)
+		for i := range population {
+			genes := make([]bool, 20)
+			for j := range genes {
+				genes[j] = rng.Float64() < 0.5
+			}
+			population[i] = &OneMaxChromosome{Genes: genes}
 		}
-		population[i] = &OneMaxChromosome{Genes: genes}
-	}
+		return population
+	})
 
 	// Create a new genetic algorithm.
 	geneticAlgorithm := ga.New(
-		ga.WithPopulation(population),
+		ga.WithInitializer(bitStrings, 100),
 		ga.WithMutationRate(0.01),
 		ga.WithCrossoverRate(0.8),
 		ga.WithGenerations(100),
@@ -107,22 +128,24 @@ func runTSP() {
 
 	fmt.Printf("Loaded %d cities for TSP\n", len(cities))
 
-	// Create an initial population.
-	population := make([]ga.Chromosome, 100)
-	for i := range population {
-		route := make([]ga.City, len(cities))
-		copy(route, cities)
-		rand.Shuffle(len(route), func(i, j int) {
-			route[i], route[j] = route[j], route[i]
-		})
-		population[i] = &ga.TSPChromosome{Route: route}
+	// Seed a tenth of the initial population with nearest-neighbour tours
+	// and shuffle the rest.
+	routes := ga.TSPRoutes(cities)
+	initializer := ga.MixedInitializer{
+		Heuristic: ga.NearestNeighbourInitializer{
+			Length:   len(cities),
+			Distance: ga.TSPDistance(cities),
+			Build:    routes,
+		},
+		Random:            ga.ShuffleInitializer{Length: len(cities), Build: routes},
+		HeuristicFraction: 0.1,
 	}
 
 	fmt.Println("Running genetic algorithm...")
 
 	// Create a new genetic algorithm.
 	geneticAlgorithm := ga.New(
-		ga.WithPopulation(population),
+		ga.WithInitializer(initializer, 100),
 		ga.WithMutationRate(0.02),
 		ga.WithCrossoverRate(0.85),
 		ga.WithGenerations(200),
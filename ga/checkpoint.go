@@ -0,0 +1,573 @@
+package ga
+
+import (
+	"bytes"
+	"context"
+	"encoding/gob"
+	"fmt"
+	"io"
+	"math"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sync"
+)
+
+// Checkpoint is a snapshot of a run taken at a generation boundary. It holds
+// everything needed to continue the run so that the resumed run produces the
+// same result as an uninterrupted run with the same seed and options.
+type Checkpoint struct {
+	// Generation is the next generation to evaluate.
+	Generation int
+
+	// Population is the population about to be evaluated, together with
+	// the cached fitness and constraint violation of each chromosome (valid
+	// where Evaluated is true). Violation may be empty for unconstrained
+	// problems.
+	Population []Chromosome
+	Fitness    []float64
+	Violation  []float64
+	Evaluated  []bool
+
+	// ParentFitness holds, for offspring not yet evaluated, the fitness of
+	// their fitter parent, and NaN for the other chromosomes. It may be
+	// empty.
+	ParentFitness []float64
+
+	// Best is the best chromosome found so far, and BestFitness and
+	// BestViolation its scores. Best is nil if no generation has been
+	// evaluated yet.
+	Best          Chromosome
+	BestFitness   float64
+	BestViolation float64
+
+	// HallOfFame holds the WithHallOfFame entries, best first, and
+	// HallOfFameFitness and HallOfFameViolation their scores. They are empty
+	// without a hall of fame.
+	HallOfFame          []Chromosome
+	HallOfFameFitness   []float64
+	HallOfFameViolation []float64
+
+	// LastBestFitness and GenerationsWithoutImprovement are the
+	// WithConvergence plateau counters.
+	LastBestFitness               float64
+	GenerationsWithoutImprovement int
+
+	// PenaltyWeight, FeasibleStreak and InfeasibleStreak are the
+	// WithAdaptivePenalty state.
+	PenaltyWeight    float64
+	FeasibleStreak   int
+	InfeasibleStreak int
+
+	// Seed and Draws restore the RNG: it is re-seeded with Seed and advanced
+	// by Draws values.
+	Seed  int64
+	Draws uint64
+
+	// Stats are the run statistics accumulated so far.
+	Stats Stats
+}
+
+// Checkpointer persists checkpoints of a running GA. Save is called every
+// few generations as configured with WithCheckpointer; Load returns the most
+// recently saved checkpoint.
+type Checkpointer interface {
+	Save(cp *Checkpoint) error
+	Load() (*Checkpoint, error)
+}
+
+// FileCheckpointer is a Checkpointer that keeps the latest checkpoint in a
+// single file. Saves are atomic: the checkpoint is written to a temporary
+// file in the same directory which then replaces Path, so a crash during a
+// save leaves the previous checkpoint intact.
+type FileCheckpointer struct {
+	Path string
+}
+
+// Save writes cp to f.Path.
+func (f *FileCheckpointer) Save(cp *Checkpoint) error {
+	tmp, err := os.CreateTemp(filepath.Dir(f.Path), filepath.Base(f.Path)+".tmp*")
+	if err != nil {
+		return fmt.Errorf("failed to create checkpoint file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if err := EncodeCheckpoint(tmp, cp); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write checkpoint file: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), f.Path); err != nil {
+		return fmt.Errorf("failed to replace checkpoint file: %w", err)
+	}
+	return nil
+}
+
+// Load reads the checkpoint stored at f.Path.
+func (f *FileCheckpointer) Load() (*Checkpoint, error) {
+	file, err := os.Open(f.Path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open checkpoint file %s: %w", f.Path, err)
+	}
+	defer file.Close()
+
+	return DecodeCheckpoint(file)
+}
+
+// WithCheckpointer saves a checkpoint through cp after every 'every'
+// generations. A failing save aborts the run with an error.
+//
+// Every chromosome type in the population must have a registered Codec (see
+// RegisterCodec). Resume the run with GA.Resume using the same options.
+// A standalone Engine does not support checkpoints: only a GA converts its
+// individuals to and from the Chromosomes that a Codec encodes.
+//
+// Example:
+//
+//	ga.WithCheckpointer(&ga.FileCheckpointer{Path: "run.ckpt"}, 10)
+func WithCheckpointer(cp Checkpointer, every int) func(*GA) {
+	return func(ga *GA) {
+		ga.checkpointer = cp
+		ga.checkpointInterval = every
+	}
+}
+
+// Codec serializes chromosomes of one concrete type for checkpoints.
+type Codec interface {
+	Encode(c Chromosome) ([]byte, error)
+	Decode(data []byte) (Chromosome, error)
+}
+
+// GobCodec is a Codec for chromosome types that encoding/gob can handle,
+// i.e. whose state is held in exported fields.
+//
+// Example:
+//
+//	ga.RegisterCodec("myapp.Route", &Route{}, ga.GobCodec[*Route]{})
+type GobCodec[C Chromosome] struct{}
+
+// Encode gob-encodes c, which must be of type C.
+func (GobCodec[C]) Encode(c Chromosome) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(c.(C)); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// Decode gob-decodes a chromosome of type C.
+func (GobCodec[C]) Decode(data []byte) (Chromosome, error) {
+	var c C
+	if err := gob.NewDecoder(bytes.NewReader(data)).Decode(&c); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+var codecs = struct {
+	sync.RWMutex
+	byName map[string]Codec
+	byType map[reflect.Type]string
+}{
+	byName: make(map[string]Codec),
+	byType: make(map[reflect.Type]string),
+}
+
+// RegisterCodec registers the codec used to checkpoint chromosomes with the
+// same dynamic type as prototype. name identifies the codec in checkpoint
+// files and must stay stable across program versions. Registering a name or
+// type twice panics, as it almost always indicates a programming error.
+func RegisterCodec(name string, prototype Chromosome, codec Codec) {
+	codecs.Lock()
+	defer codecs.Unlock()
+
+	t := reflect.TypeOf(prototype)
+	if _, dup := codecs.byName[name]; dup {
+		panic(fmt.Sprintf("ga: codec %q registered twice", name))
+	}
+	if existing, dup := codecs.byType[t]; dup {
+		panic(fmt.Sprintf("ga: type %v already registered as codec %q", t, existing))
+	}
+	codecs.byName[name] = codec
+	codecs.byType[t] = name
+}
+
+// encodedChromosome is a chromosome serialized by its registered codec.
+type encodedChromosome struct {
+	Codec string
+	Data  []byte
+}
+
+func encodeChromosome(c Chromosome) (encodedChromosome, error) {
+	codecs.RLock()
+	name, ok := codecs.byType[reflect.TypeOf(c)]
+	codec := codecs.byName[name]
+	codecs.RUnlock()
+	if !ok {
+		return encodedChromosome{}, fmt.Errorf("no codec registered for chromosome type %T", c)
+	}
+
+	data, err := codec.Encode(c)
+	if err != nil {
+		return encodedChromosome{}, fmt.Errorf("codec %q failed to encode chromosome: %w", name, err)
+	}
+	return encodedChromosome{Codec: name, Data: data}, nil
+}
+
+func decodeChromosome(ec encodedChromosome) (Chromosome, error) {
+	codecs.RLock()
+	codec, ok := codecs.byName[ec.Codec]
+	codecs.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("no codec registered under name %q", ec.Codec)
+	}
+
+	c, err := codec.Decode(ec.Data)
+	if err != nil {
+		return nil, fmt.Errorf("codec %q failed to decode chromosome: %w", ec.Codec, err)
+	}
+	return c, nil
+}
+
+// checkpointVersion is bumped whenever checkpointFile changes incompatibly.
+const checkpointVersion = 1
+
+// checkpointFile is the serialized form of a Checkpoint.
+type checkpointFile struct {
+	Version    int
+	Generation int
+
+	Population    []encodedChromosome
+	Fitness       []float64
+	Violation     []float64
+	Evaluated     []bool
+	ParentFitness []float64
+
+	Best          *encodedChromosome
+	BestFitness   float64
+	BestViolation float64
+
+	HallOfFame          []encodedChromosome
+	HallOfFameFitness   []float64
+	HallOfFameViolation []float64
+
+	LastBestFitness               float64
+	GenerationsWithoutImprovement int
+
+	PenaltyWeight    float64
+	FeasibleStreak   int
+	InfeasibleStreak int
+
+	Seed  int64
+	Draws uint64
+
+	Stats Stats
+}
+
+// EncodeCheckpoint writes cp to w. Use it to implement a Checkpointer that
+// stores checkpoints somewhere other than the local file system.
+func EncodeCheckpoint(w io.Writer, cp *Checkpoint) error {
+	file := checkpointFile{
+		Version:                       checkpointVersion,
+		Generation:                    cp.Generation,
+		Population:                    make([]encodedChromosome, len(cp.Population)),
+		Fitness:                       cp.Fitness,
+		Violation:                     cp.Violation,
+		ParentFitness:                 cp.ParentFitness,
+		Evaluated:                     cp.Evaluated,
+		BestFitness:                   cp.BestFitness,
+		BestViolation:                 cp.BestViolation,
+		HallOfFame:                    make([]encodedChromosome, len(cp.HallOfFame)),
+		HallOfFameFitness:             cp.HallOfFameFitness,
+		HallOfFameViolation:           cp.HallOfFameViolation,
+		LastBestFitness:               cp.LastBestFitness,
+		GenerationsWithoutImprovement: cp.GenerationsWithoutImprovement,
+		PenaltyWeight:                 cp.PenaltyWeight,
+		FeasibleStreak:                cp.FeasibleStreak,
+		InfeasibleStreak:              cp.InfeasibleStreak,
+		Seed:                          cp.Seed,
+		Draws:                         cp.Draws,
+		Stats:                         cp.Stats,
+	}
+
+	for i, c := range cp.Population {
+		ec, err := encodeChromosome(c)
+		if err != nil {
+			return fmt.Errorf("population index %d: %w", i, err)
+		}
+		file.Population[i] = ec
+	}
+	if cp.Best != nil {
+		ec, err := encodeChromosome(cp.Best)
+		if err != nil {
+			return fmt.Errorf("best chromosome: %w", err)
+		}
+		file.Best = &ec
+	}
+	for i, c := range cp.HallOfFame {
+		ec, err := encodeChromosome(c)
+		if err != nil {
+			return fmt.Errorf("hall of fame index %d: %w", i, err)
+		}
+		file.HallOfFame[i] = ec
+	}
+
+	if err := gob.NewEncoder(w).Encode(&file); err != nil {
+		return fmt.Errorf("failed to encode checkpoint: %w", err)
+	}
+	return nil
+}
+
+// DecodeCheckpoint reads a checkpoint written by EncodeCheckpoint.
+func DecodeCheckpoint(r io.Reader) (*Checkpoint, error) {
+	var file checkpointFile
+	if err := gob.NewDecoder(r).Decode(&file); err != nil {
+		return nil, fmt.Errorf("failed to decode checkpoint: %w", err)
+	}
+	if file.Version != checkpointVersion {
+		return nil, fmt.Errorf("unsupported checkpoint version %d, expected %d", file.Version, checkpointVersion)
+	}
+	if len(file.Fitness) != len(file.Population) || len(file.Evaluated) != len(file.Population) {
+		return nil, fmt.Errorf("corrupt checkpoint: %d chromosomes but %d fitness values and %d evaluated flags",
+			len(file.Population), len(file.Fitness), len(file.Evaluated))
+	}
+	if len(file.Violation) != 0 && len(file.Violation) != len(file.Population) ||
+		len(file.ParentFitness) != 0 && len(file.ParentFitness) != len(file.Population) {
+		return nil, fmt.Errorf("corrupt checkpoint: %d chromosomes but %d violations and %d parent fitness values",
+			len(file.Population), len(file.Violation), len(file.ParentFitness))
+	}
+
+	cp := &Checkpoint{
+		Generation:                    file.Generation,
+		Population:                    make([]Chromosome, len(file.Population)),
+		Fitness:                       file.Fitness,
+		Violation:                     file.Violation,
+		ParentFitness:                 file.ParentFitness,
+		Evaluated:                     file.Evaluated,
+		BestFitness:                   file.BestFitness,
+		BestViolation:                 file.BestViolation,
+		HallOfFame:                    make([]Chromosome, len(file.HallOfFame)),
+		HallOfFameFitness:             file.HallOfFameFitness,
+		HallOfFameViolation:           file.HallOfFameViolation,
+		LastBestFitness:               file.LastBestFitness,
+		GenerationsWithoutImprovement: file.GenerationsWithoutImprovement,
+		PenaltyWeight:                 file.PenaltyWeight,
+		FeasibleStreak:                file.FeasibleStreak,
+		InfeasibleStreak:              file.InfeasibleStreak,
+		Seed:                          file.Seed,
+		Draws:                         file.Draws,
+		Stats:                         file.Stats,
+	}
+
+	for i, ec := range file.Population {
+		c, err := decodeChromosome(ec)
+		if err != nil {
+			return nil, fmt.Errorf("population index %d: %w", i, err)
+		}
+		cp.Population[i] = c
+	}
+	if file.Best != nil {
+		c, err := decodeChromosome(*file.Best)
+		if err != nil {
+			return nil, fmt.Errorf("best chromosome: %w", err)
+		}
+		cp.Best = c
+	}
+	for i, ec := range file.HallOfFame {
+		c, err := decodeChromosome(ec)
+		if err != nil {
+			return nil, fmt.Errorf("hall of fame index %d: %w", i, err)
+		}
+		cp.HallOfFame[i] = c
+	}
+
+	return cp, nil
+}
+
+// Resume loads the checkpoint at path and continues the run it describes.
+// The GA must be configured with the same options as the interrupted run
+// (WithPopulation is not needed); the population, best chromosome, RNG state
+// and counters are taken from the checkpoint.
+func (ga *GA) Resume(path string) (Result, error) {
+	return ga.ResumeContext(context.Background(), path)
+}
+
+// ResumeContext is like Resume but stops early when ctx is cancelled, as
+// RunContext does.
+func (ga *GA) ResumeContext(ctx context.Context, path string) (Result, error) {
+	return ga.ResumeFrom(ctx, &FileCheckpointer{Path: path})
+}
+
+// ResumeFrom loads the latest checkpoint from checkpointer and continues the
+// run it describes, like ResumeContext. Use it to resume from checkpoints
+// stored somewhere other than the local file system, typically with the
+// Checkpointer the interrupted run was configured with.
+//
+// Example:
+//
+//	store := &BucketCheckpointer{Bucket: bucket, Key: "run.ckpt"}
+//	result, err := ga.New(
+//	    ga.WithCheckpointer(store, 10),
+//	).ResumeFrom(ctx, store)
+func (ga *GA) ResumeFrom(ctx context.Context, checkpointer Checkpointer) (Result, error) {
+	cp, err := checkpointer.Load()
+	if err != nil {
+		return Result{}, fmt.Errorf("failed to resume: %w", err)
+	}
+
+	ga.Population = cp.Population
+	ga.BestChromosome = cp.Best
+	if err := ga.Validate(); err != nil {
+		return Result{}, fmt.Errorf("invalid GA configuration: %w", err)
+	}
+
+	engine := ga.engine()
+	if err := engine.restore(cp); err != nil {
+		return Result{}, fmt.Errorf("failed to resume: %w", err)
+	}
+	return ga.runEngine(ctx, engine)
+}
+
+// saveCheckpoint captures state, which is about to evaluate generation, and
+// hands it to the configured Checkpointer.
+func (e *Engine[T]) saveCheckpoint(state *runState[T], generation int) error {
+	cp := &Checkpoint{
+		Generation:                    generation,
+		Population:                    make([]Chromosome, len(state.population)),
+		Fitness:                       make([]float64, len(state.population)),
+		Violation:                     make([]float64, len(state.population)),
+		ParentFitness:                 make([]float64, len(state.population)),
+		Evaluated:                     make([]bool, len(state.population)),
+		BestFitness:                   state.bestFitness,
+		BestViolation:                 state.bestViolation,
+		LastBestFitness:               state.lastBestFitness,
+		GenerationsWithoutImprovement: state.generationsWithoutImprovement,
+		PenaltyWeight:                 state.penaltyWeight,
+		FeasibleStreak:                state.feasibleStreak,
+		InfeasibleStreak:              state.infeasibleStreak,
+		Seed:                          e.config.source.seed,
+		Draws:                         e.config.source.draws,
+		Stats:                         state.stats,
+	}
+	for i, m := range state.population {
+		cp.Population[i] = e.chromosomeOf(m.value, m.fitness)
+		cp.Fitness[i] = m.fitness
+		cp.Violation[i] = m.violation
+		cp.ParentFitness[i] = math.NaN()
+		if m.bred {
+			cp.ParentFitness[i] = m.parentFitness
+		}
+		cp.Evaluated[i] = m.evaluated
+	}
+	if e.hasBest {
+		cp.Best = e.chromosomeOf(e.best, state.bestFitness)
+	}
+	for _, m := range e.hallOfFame {
+		cp.HallOfFame = append(cp.HallOfFame, e.chromosomeOf(m.value, m.fitness))
+		cp.HallOfFameFitness = append(cp.HallOfFameFitness, m.fitness)
+		cp.HallOfFameViolation = append(cp.HallOfFameViolation, m.violation)
+	}
+	return e.config.checkpointer.Save(cp)
+}
+
+// restore loads cp into the engine so that the next RunContext continues the
+// checkpointed run.
+func (e *Engine[T]) restore(cp *Checkpoint) error {
+	population := make([]*member[T], len(cp.Population))
+	values := make([]T, len(cp.Population))
+	for i, c := range cp.Population {
+		value, ok := e.individualFrom(c)
+		if !ok {
+			return fmt.Errorf("checkpoint chromosome %T is not a %T", c, value)
+		}
+		population[i] = &member[T]{value: value, fitness: cp.Fitness[i], evaluated: cp.Evaluated[i]}
+		if len(cp.Violation) == len(cp.Population) {
+			population[i].violation = cp.Violation[i]
+		}
+		if len(cp.ParentFitness) == len(cp.Population) && !math.IsNaN(cp.ParentFitness[i]) {
+			population[i].bred = true
+			population[i].parentFitness = cp.ParentFitness[i]
+		}
+		values[i] = value
+	}
+
+	var best T
+	if cp.Best != nil {
+		var ok bool
+		if best, ok = e.individualFrom(cp.Best); !ok {
+			return fmt.Errorf("checkpoint chromosome %T is not a %T", cp.Best, best)
+		}
+	}
+
+	hallOfFame, err := e.hallOfFameOf(cp.HallOfFame, cp.HallOfFameFitness, cp.HallOfFameViolation)
+	if err != nil {
+		return err
+	}
+
+	e.Population = values
+	e.best = best
+	e.hasBest = cp.Best != nil
+	e.hallOfFame = hallOfFame
+	e.config.setRandomState(cp.Seed, cp.Draws)
+	e.resumeState = &runState[T]{
+		generation:                    cp.Generation,
+		population:                    population,
+		bestFitness:                   cp.BestFitness,
+		bestViolation:                 cp.BestViolation,
+		lastBestFitness:               cp.LastBestFitness,
+		generationsWithoutImprovement: cp.GenerationsWithoutImprovement,
+		penaltyWeight:                 cp.PenaltyWeight,
+		feasibleStreak:                cp.FeasibleStreak,
+		infeasibleStreak:              cp.InfeasibleStreak,
+		stats:                         cp.Stats,
+		mutationRate:                  e.config.MutationRate,
+		crossoverRate:                 e.config.CrossoverRate,
+	}
+	if history := cp.Stats.History; len(history) > 0 {
+		last := history[len(history)-1]
+		e.resumeState.mutationRate = last.MutationRate
+		e.resumeState.crossoverRate = last.CrossoverRate
+	}
+	return nil
+}
+
+// countingSource is the GA's random source. It wraps the standard math/rand
+// source and counts the values drawn from it, so its state can be captured
+// in a checkpoint as (seed, draws) and restored by replaying the draws.
+type countingSource struct {
+	src   rand.Source64
+	seed  int64
+	draws uint64
+}
+
+func newCountingSource(seed int64) *countingSource {
+	return &countingSource{src: rand.NewSource(seed).(rand.Source64), seed: seed}
+}
+
+func (s *countingSource) Int63() int64 {
+	s.draws++
+	return s.src.Int63()
+}
+
+func (s *countingSource) Uint64() uint64 {
+	s.draws++
+	return s.src.Uint64()
+}
+
+func (s *countingSource) Seed(seed int64) {
+	s.src.Seed(seed)
+	s.seed = seed
+	s.draws = 0
+}
+
+// setRandomState re-seeds the GA's RNG and advances it by draws values.
+func (ga *GA) setRandomState(seed int64, draws uint64) {
+	ga.source = newCountingSource(seed)
+	for ga.source.draws < draws {
+		ga.source.Uint64()
+	}
+	ga.rng = rand.New(ga.source)
+}
@@ -0,0 +1,256 @@
+package ga
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"math"
+	"math/rand"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func newTSPPopulation(size, cities int, seed int64) []Chromosome {
+	route := make([]City, cities)
+	for i := range route {
+		angle := float64(i) * 2 * math.Pi / float64(cities)
+		route[i] = City{Name: string(rune('A' + i)), X: 10 * math.Cos(angle), Y: 10 * math.Sin(angle)}
+	}
+
+	rng := rand.New(rand.NewSource(seed))
+	population := make([]Chromosome, size)
+	for i := range population {
+		shuffled := make([]City, len(route))
+		copy(shuffled, route)
+		rng.Shuffle(len(shuffled), func(a, b int) { shuffled[a], shuffled[b] = shuffled[b], shuffled[a] })
+		population[i] = &TSPChromosome{Route: shuffled}
+	}
+	return population
+}
+
+func routeNames(c Chromosome) string {
+	var names strings.Builder
+	for _, city := range c.(*TSPChromosome).Route {
+		names.WriteString(city.Name)
+	}
+	return names.String()
+}
+
+// TestResumeMatchesUninterruptedRun verifies a run interrupted after a
+// checkpoint and resumed from it ends exactly like an uninterrupted run
+func TestResumeMatchesUninterruptedRun(t *testing.T) {
+	options := func() []func(*GA) {
+		return []func(*GA){
+			WithGenerations(30),
+			WithMutationRate(0.3),
+			WithConvergence(50, 0),
+			WithRandomSeed(2024),
+		}
+	}
+
+	// Uninterrupted reference run
+	reference := New(append(options(), WithPopulation(newTSPPopulation(30, 10, 1)))...)
+	want, err := reference.RunContext(context.Background())
+	if err != nil {
+		t.Fatalf("Reference run failed: %v", err)
+	}
+
+	// Interrupted run: checkpoint every 5 generations, crash during 17
+	path := filepath.Join(t.TempDir(), "run.ckpt")
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	interrupted := New(append(options(),
+		WithPopulation(newTSPPopulation(30, 10, 1)),
+		WithCheckpointer(&FileCheckpointer{Path: path}, 5),
+		WithProgressCallback(func(generation int, best Chromosome) {
+			if generation == 17 {
+				cancel()
+			}
+		}),
+	)...)
+	if _, err := interrupted.RunContext(ctx); !errors.Is(err, context.Canceled) {
+		t.Fatalf("Expected interrupted run to be cancelled, got %v", err)
+	}
+
+	// Resume with a fresh GA and a different seed, which the checkpoint overrides
+	resumed := New(WithGenerations(30), WithMutationRate(0.3), WithConvergence(50, 0), WithRandomSeed(1))
+	got, err := resumed.Resume(path)
+	if err != nil {
+		t.Fatalf("Resume failed: %v", err)
+	}
+
+	if routeNames(got.Best) != routeNames(want.Best) {
+		t.Errorf("Best route differs: resumed %s, uninterrupted %s", routeNames(got.Best), routeNames(want.Best))
+	}
+	for i := range reference.Population {
+		if routeNames(resumed.Population[i]) != routeNames(reference.Population[i]) {
+			t.Fatalf("Final population differs at index %d", i)
+		}
+	}
+	if got.Generations != want.Generations || got.Evaluations != want.Evaluations {
+		t.Errorf("Expected %d generations and %d evaluations, got %d and %d",
+			want.Generations, want.Evaluations, got.Generations, got.Evaluations)
+	}
+	if len(got.History) != len(want.History) {
+		t.Errorf("Expected %d history entries, got %d", len(want.History), len(got.History))
+	}
+}
+
+// memoryCheckpointer keeps the latest encoded checkpoint in memory and
+// counts the saves
+type memoryCheckpointer struct {
+	data  []byte
+	saves int
+}
+
+func (m *memoryCheckpointer) Save(cp *Checkpoint) error {
+	var buf bytes.Buffer
+	if err := EncodeCheckpoint(&buf, cp); err != nil {
+		return err
+	}
+	m.data = buf.Bytes()
+	m.saves++
+	return nil
+}
+
+func (m *memoryCheckpointer) Load() (*Checkpoint, error) {
+	if m.data == nil {
+		return nil, errors.New("no checkpoint saved")
+	}
+	return DecodeCheckpoint(bytes.NewReader(m.data))
+}
+
+// TestResumeFromCheckpointer verifies a run checkpointed to a custom store
+// resumes from it and ends like an uninterrupted run
+func TestResumeFromCheckpointer(t *testing.T) {
+	options := func() []func(*GA) {
+		return []func(*GA){
+			WithGenerations(20),
+			WithRandomSeed(9),
+		}
+	}
+
+	reference := New(append(options(), WithPopulation(newTSPPopulation(20, 8, 1)))...)
+	want, err := reference.RunContext(context.Background())
+	if err != nil {
+		t.Fatalf("Reference run failed: %v", err)
+	}
+
+	store := &memoryCheckpointer{}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	interrupted := New(append(options(),
+		WithPopulation(newTSPPopulation(20, 8, 1)),
+		WithCheckpointer(store, 4),
+		WithProgressCallback(func(generation int, best Chromosome) {
+			if generation == 10 {
+				cancel()
+			}
+		}),
+	)...)
+	if _, err := interrupted.RunContext(ctx); !errors.Is(err, context.Canceled) {
+		t.Fatalf("Expected interrupted run to be cancelled, got %v", err)
+	}
+	if store.saves != 2 {
+		t.Fatalf("Expected 2 saves before the interruption, got %d", store.saves)
+	}
+
+	got, err := New(options()...).ResumeFrom(context.Background(), store)
+	if err != nil {
+		t.Fatalf("ResumeFrom failed: %v", err)
+	}
+	if routeNames(got.Best) != routeNames(want.Best) || got.Evaluations != want.Evaluations {
+		t.Errorf("Resumed run ended with %s after %d evaluations, expected %s after %d",
+			routeNames(got.Best), got.Evaluations, routeNames(want.Best), want.Evaluations)
+	}
+
+	if _, err := New(options()...).ResumeFrom(context.Background(), &memoryCheckpointer{}); err == nil {
+		t.Error("Expected an error when the store holds no checkpoint")
+	}
+}
+
+// TestCheckpointEncodeDecode verifies a checkpoint round-trips, including
+// infinite fitness values
+func TestCheckpointEncodeDecode(t *testing.T) {
+	population := newTSPPopulation(3, 4, 1)
+	cp := &Checkpoint{
+		Generation:      7,
+		Population:      population,
+		Fitness:         []float64{math.Inf(1), 0.5, 0.25},
+		Evaluated:       []bool{true, true, false},
+		Best:            population[0],
+		BestFitness:     math.Inf(1),
+		LastBestFitness: math.Inf(-1),
+		Seed:            42,
+		Draws:           1234,
+		Stats:           Stats{Generations: 7, Evaluations: 20},
+	}
+
+	var buf bytes.Buffer
+	if err := EncodeCheckpoint(&buf, cp); err != nil {
+		t.Fatalf("Encode failed: %v", err)
+	}
+	decoded, err := DecodeCheckpoint(&buf)
+	if err != nil {
+		t.Fatalf("Decode failed: %v", err)
+	}
+
+	if decoded.Generation != 7 || decoded.Seed != 42 || decoded.Draws != 1234 {
+		t.Errorf("Scalar fields did not round-trip: %+v", decoded)
+	}
+	if !math.IsInf(decoded.Fitness[0], 1) || !math.IsInf(decoded.LastBestFitness, -1) {
+		t.Error("Infinite fitness values did not round-trip")
+	}
+	if routeNames(decoded.Best) != routeNames(cp.Best) {
+		t.Error("Best chromosome did not round-trip")
+	}
+	for i := range population {
+		if routeNames(decoded.Population[i]) != routeNames(population[i]) {
+			t.Errorf("Population index %d did not round-trip", i)
+		}
+	}
+}
+
+// TestCheckpointRequiresCodec verifies a missing codec aborts the run
+func TestCheckpointRequiresCodec(t *testing.T) {
+	ga := New(
+		WithPopulation(mockPopulation(1, 2, 3)),
+		WithGenerations(5),
+		WithCheckpointer(&FileCheckpointer{Path: filepath.Join(t.TempDir(), "run.ckpt")}, 2),
+	)
+
+	err := ga.Run()
+	if err == nil || !strings.Contains(err.Error(), "no codec registered") {
+		t.Errorf("Expected missing codec error, got %v", err)
+	}
+}
+
+// TestRandomStateRestore verifies replaying draws restores the RNG sequence
+func TestRandomStateRestore(t *testing.T) {
+	original := New(WithRandomSeed(99))
+	for i := 0; i < 100; i++ {
+		original.rng.Intn(1000)
+	}
+	original.rng.Float64()
+
+	restored := New()
+	restored.setRandomState(original.source.seed, original.source.draws)
+
+	for i := 0; i < 50; i++ {
+		if a, b := original.rng.Int63(), restored.rng.Int63(); a != b {
+			t.Fatalf("Draw %d differs after restore: %d vs %d", i, a, b)
+		}
+	}
+}
+
+// TestValidateCheckpointInterval verifies the checkpoint interval is checked
+func TestValidateCheckpointInterval(t *testing.T) {
+	ga := New(
+		WithPopulation(mockPopulation(1)),
+		WithCheckpointer(&FileCheckpointer{Path: "unused"}, 0),
+	)
+	if err := ga.Validate(); err == nil {
+		t.Error("Expected error for checkpoint interval 0, got nil")
+	}
+}
@@ -0,0 +1,201 @@
+package ga
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+)
+
+// Constrained is implemented by chromosomes (or Engine individuals) of
+// constrained problems. Instead of hiding constraint penalties in Fitness,
+// report how badly the constraints are violated and let the engine rank
+// solutions with Deb's feasibility rules:
+//
+//  1. A feasible solution (violation 0) beats an infeasible one.
+//  2. Of two infeasible solutions, the one with the smaller violation wins.
+//  3. Of two feasible solutions, the one with the higher fitness wins.
+//
+// These rules are used when sorting the population, by TournamentSelector,
+// the replacement strategies and elitism, and to pick the best solution.
+// With WithAdaptivePenalty the violation is instead subtracted from the
+// fitness seen by selectors, scaled by an adaptive weight.
+type Constrained interface {
+	// Violation returns the total amount by which the constraints are
+	// violated: 0 for a feasible solution, positive otherwise. Negative
+	// values count as 0 and NaN as +Inf.
+	Violation() float64
+}
+
+// Fitter reports whether chromosome a ranks above b under Deb's feasibility
+// rules (see Constrained). Chromosomes that do not implement Constrained are
+// feasible, so for unconstrained problems Fitter compares Fitness. Custom
+// selectors can use it to respect constraints.
+func Fitter(a, b Chromosome) bool {
+	return ranksAbove(a.Fitness(), violationOf(a), b.Fitness(), violationOf(b))
+}
+
+// ranksAbove applies Deb's feasibility rules to two (fitness, violation)
+// pairs.
+func ranksAbove(fitness, violation, otherFitness, otherViolation float64) bool {
+	if violation != otherViolation {
+		return violation < otherViolation
+	}
+	return fitness > otherFitness
+}
+
+// violationOf returns the normalized violation of individual, or 0 if it
+// does not implement Constrained.
+func violationOf(individual any) float64 {
+	c, ok := individual.(Constrained)
+	if !ok {
+		return 0
+	}
+	v := c.Violation()
+	switch {
+	case math.IsNaN(v):
+		return math.Inf(1)
+	case v < 0:
+		return 0
+	}
+	return v
+}
+
+// AdaptivePenalty configures the adaptive penalty method of Bean and
+// Hadj-Alouane. Every chromosome's fitness, as seen by selectors and
+// sorting, becomes Fitness - Weight*Violation. After each generation the
+// weight adapts: if the top-ranked chromosome was infeasible for Window
+// generations in a row the weight is multiplied by Factor, and if it was
+// feasible for Window generations in a row the weight is divided by Factor.
+// The search is thus pushed back toward feasibility when it strays and
+// allowed to explore the infeasible boundary when it is safely feasible.
+//
+// The best chromosome reported by the GA is still chosen by Deb's
+// feasibility rules on the unpenalized fitness.
+type AdaptivePenalty struct {
+	// Initial is the starting penalty weight. Default is 1 if <= 0.
+	Initial float64
+
+	// Window is the number of consecutive generations that trigger an
+	// adjustment. Default is 5 if <= 0.
+	Window int
+
+	// Factor scales the weight at each adjustment. Default is 2 if <= 1.
+	Factor float64
+}
+
+// withDefaults fills in the defaults for unset fields.
+func (p AdaptivePenalty) withDefaults() AdaptivePenalty {
+	if p.Initial <= 0 {
+		p.Initial = 1
+	}
+	if p.Window <= 0 {
+		p.Window = 5
+	}
+	if p.Factor <= 1 {
+		p.Factor = 2
+	}
+	return p
+}
+
+// WithAdaptivePenalty replaces Deb's feasibility rules with an adaptive
+// penalty on constraint violations. See AdaptivePenalty.
+//
+// Example:
+//
+//	ga.WithAdaptivePenalty(ga.AdaptivePenalty{Initial: 10, Window: 3, Factor: 1.5})
+func WithAdaptivePenalty(penalty AdaptivePenalty) func(*GA) {
+	return func(ga *GA) {
+		p := penalty.withDefaults()
+		ga.penalty = &p
+	}
+}
+
+// Repairer maps infeasible offspring back into (or closer to) the feasible
+// region, for example by clamping genes to their bounds or dropping items
+// from an over-full knapsack. It is called on every offspring produced by
+// crossover or mutation, before the offspring is evaluated.
+//
+// THREAD SAFETY: The rng parameter MUST be used for all random operations
+// instead of the global math/rand.
+type Repairer interface {
+	// Repair returns the repaired chromosome. It may modify c in place and
+	// return it, or return a new chromosome of the same type.
+	Repair(c Chromosome, rng *rand.Rand) Chromosome
+}
+
+// RepairFunc adapts an ordinary function to the Repairer interface.
+type RepairFunc func(c Chromosome, rng *rand.Rand) Chromosome
+
+// Repair calls f(c, rng).
+func (f RepairFunc) Repair(c Chromosome, rng *rand.Rand) Chromosome {
+	return f(c, rng)
+}
+
+// WithRepairer sets a repair hook applied to every new offspring after
+// crossover and mutation. For an Engine, the chromosome passed to Repair is
+// the usual Chromosome view of the individual; use IndividualOf to recover
+// the typed value.
+//
+// Example:
+//
+//	ga.WithRepairer(ga.RepairFunc(func(c ga.Chromosome, rng *rand.Rand) ga.Chromosome {
+//	    c.(*Knapsack).DropUntilFeasible(rng)
+//	    return c
+//	}))
+func WithRepairer(repairer Repairer) func(*GA) {
+	return func(ga *GA) {
+		ga.repairer = repairer
+	}
+}
+
+// repair runs the configured repairer on offspring, which starts
+// unevaluated.
+func (e *Engine[T]) repair(offspring *member[T]) (*member[T], error) {
+	repaired := e.config.repairer.Repair(e.chromosomeOf(offspring.value, offspring.fitness), e.config.rng)
+	if repaired == nil {
+		return nil, fmt.Errorf("repairer returned nil")
+	}
+	if m, ok := repaired.(*member[T]); ok {
+		return &member[T]{value: m.value}, nil
+	}
+	value, ok := e.individualFrom(repaired)
+	if !ok {
+		return nil, fmt.Errorf("repairer returned %T, which is not a %T", repaired, value)
+	}
+	return &member[T]{value: value}, nil
+}
+
+// applyPenalty folds the current penalty weight into the fitness that
+// selectors and sorting see. It does nothing without WithAdaptivePenalty.
+func (e *Engine[T]) applyPenalty(population []*member[T], weight float64) {
+	if e.config.penalty == nil {
+		return
+	}
+	for _, m := range population {
+		m.penalty = weight * m.violation
+	}
+}
+
+// adaptPenalty updates the penalty weight from the feasibility of the
+// top-ranked member of the sorted population.
+func (e *Engine[T]) adaptPenalty(state *runState[T], top *member[T]) {
+	p := e.config.penalty
+	if p == nil {
+		return
+	}
+	if top.violation > 0 {
+		state.feasibleStreak = 0
+		state.infeasibleStreak++
+		if state.infeasibleStreak >= p.Window {
+			state.penaltyWeight *= p.Factor
+			state.infeasibleStreak = 0
+		}
+	} else {
+		state.infeasibleStreak = 0
+		state.feasibleStreak++
+		if state.feasibleStreak >= p.Window {
+			state.penaltyWeight /= p.Factor
+			state.feasibleStreak = 0
+		}
+	}
+}
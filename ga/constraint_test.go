@@ -0,0 +1,228 @@
+package ga
+
+import (
+	"bytes"
+	"context"
+	"math"
+	"math/rand"
+	"testing"
+)
+
+// boundedChromosome maximizes x subject to x <= limit.
+type boundedChromosome struct {
+	x, limit int
+}
+
+func (c *boundedChromosome) Fitness() float64 { return float64(c.x) }
+
+func (c *boundedChromosome) Violation() float64 {
+	return math.Max(0, float64(c.x-c.limit))
+}
+
+func (c *boundedChromosome) Crossover(other Chromosome) Chromosome {
+	return &boundedChromosome{x: (c.x + other.(*boundedChromosome).x) / 2, limit: c.limit}
+}
+
+func (c *boundedChromosome) CrossoverRand(other Chromosome, rng *rand.Rand) Chromosome {
+	if rng.Intn(2) == 0 {
+		return c.Clone()
+	}
+	return other.Clone()
+}
+
+func (c *boundedChromosome) Mutate() { c.x++ }
+
+func (c *boundedChromosome) MutateRand(rng *rand.Rand) { c.x += rng.Intn(7) - 2 }
+
+func (c *boundedChromosome) Clone() Chromosome { return &boundedChromosome{x: c.x, limit: c.limit} }
+
+func newBoundedPopulation(size, limit int, seed int64) []Chromosome {
+	rng := rand.New(rand.NewSource(seed))
+	population := make([]Chromosome, size)
+	for i := range population {
+		population[i] = &boundedChromosome{x: rng.Intn(2 * limit), limit: limit}
+	}
+	return population
+}
+
+// TestFitterDebRules verifies Deb's three feasibility rules
+func TestFitterDebRules(t *testing.T) {
+	feasibleLow := &boundedChromosome{x: 3, limit: 10}
+	feasibleHigh := &boundedChromosome{x: 8, limit: 10}
+	slightlyOver := &boundedChromosome{x: 12, limit: 10}
+	farOver := &boundedChromosome{x: 50, limit: 10}
+
+	if !Fitter(feasibleLow, farOver) || Fitter(farOver, feasibleLow) {
+		t.Error("A feasible solution must beat an infeasible one")
+	}
+	if !Fitter(slightlyOver, farOver) {
+		t.Error("The smaller violation must win between infeasible solutions")
+	}
+	if !Fitter(feasibleHigh, feasibleLow) {
+		t.Error("The higher fitness must win between feasible solutions")
+	}
+	if !Fitter(&MockChromosome{fitness: 2}, &MockChromosome{fitness: 1}) {
+		t.Error("Unconstrained chromosomes must be compared by fitness")
+	}
+}
+
+// TestTournamentSelectorPrefersFeasible verifies tournaments are decided by
+// feasibility before fitness
+func TestTournamentSelectorPrefersFeasible(t *testing.T) {
+	population := []Chromosome{
+		&boundedChromosome{x: 40, limit: 10},
+		&boundedChromosome{x: 9, limit: 10},
+	}
+
+	// With two competitors the feasible one wins unless it is not drawn at
+	// all, i.e. about 75% of the time; by fitness it would win only 25%
+	counts := selectionCounts(&TournamentSelector{TournamentSize: 2}, population, 2000, 1)
+	if share := float64(counts[1]) / 4000; math.Abs(share-0.75) > 0.03 {
+		t.Errorf("Expected the feasible chromosome ~75%% of the time, got %.3f", share)
+	}
+}
+
+// TestConstrainedRunFindsFeasibleOptimum verifies the engine sorts, keeps
+// elites and reports the best solution by Deb's rules
+func TestConstrainedRunFindsFeasibleOptimum(t *testing.T) {
+	algorithm := New(
+		WithPopulation(newBoundedPopulation(30, 10, 1)),
+		WithGenerations(40),
+		WithMutationRate(0.5),
+		WithEliteCount(2),
+		WithRandomSeed(2),
+	)
+	result, err := algorithm.RunContext(context.Background())
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	if best := result.Best.(*boundedChromosome); best.x != 10 {
+		t.Errorf("Expected the constrained optimum x=10, got x=%d", best.x)
+	}
+}
+
+// TestTargetFitnessRequiresFeasibility verifies an infeasible chromosome
+// never satisfies the target fitness
+func TestTargetFitnessRequiresFeasibility(t *testing.T) {
+	population := []Chromosome{&boundedChromosome{x: 100, limit: 10}, &boundedChromosome{x: 200, limit: 10}}
+	algorithm := New(
+		WithPopulation(population),
+		WithGenerations(3),
+		WithMutationRate(0),
+		WithCrossoverRate(0),
+		WithTargetFitness(50),
+	)
+	result, err := algorithm.RunContext(context.Background())
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	if result.StopReason == StopTargetReached {
+		t.Error("Expected an infeasible best not to reach the target")
+	}
+}
+
+// TestAdaptivePenaltyWeight verifies the weight grows while the top
+// individual is infeasible and shrinks while it is feasible
+func TestAdaptivePenaltyWeight(t *testing.T) {
+	engine := New(WithAdaptivePenalty(AdaptivePenalty{Initial: 4, Window: 2, Factor: 2})).engine()
+	state := &runState[chromosomeIndividual]{penaltyWeight: 4}
+	infeasible := &member[chromosomeIndividual]{violation: 1}
+	feasible := &member[chromosomeIndividual]{}
+
+	engine.adaptPenalty(state, infeasible)
+	if state.penaltyWeight != 4 {
+		t.Fatalf("Expected no change after one generation, got %v", state.penaltyWeight)
+	}
+	engine.adaptPenalty(state, infeasible)
+	if state.penaltyWeight != 8 {
+		t.Fatalf("Expected the weight to double, got %v", state.penaltyWeight)
+	}
+	engine.adaptPenalty(state, feasible)
+	engine.adaptPenalty(state, feasible)
+	engine.adaptPenalty(state, feasible)
+	engine.adaptPenalty(state, feasible)
+	if state.penaltyWeight != 2 {
+		t.Errorf("Expected the weight to halve twice, got %v", state.penaltyWeight)
+	}
+}
+
+// TestAdaptivePenaltyRun verifies a penalized run still reports a feasible
+// best and that selectors see the penalized fitness
+func TestAdaptivePenaltyRun(t *testing.T) {
+	algorithm := New(
+		WithPopulation(newBoundedPopulation(30, 10, 3)),
+		WithGenerations(40),
+		WithMutationRate(0.5),
+		WithAdaptivePenalty(AdaptivePenalty{Initial: 5}),
+		WithRandomSeed(4),
+	)
+	result, err := algorithm.RunContext(context.Background())
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	if best := result.Best.(*boundedChromosome); best.Violation() != 0 {
+		t.Errorf("Expected a feasible best, got x=%d", best.x)
+	}
+
+	m := &member[chromosomeIndividual]{fitness: 12, violation: 2}
+	New(WithAdaptivePenalty(AdaptivePenalty{})).engine().applyPenalty([]*member[chromosomeIndividual]{m}, 3)
+	if m.Fitness() != 6 || m.Violation() != 0 {
+		t.Errorf("Expected penalized fitness 6 and no remaining violation, got %v and %v", m.Fitness(), m.Violation())
+	}
+}
+
+// TestRepairerKeepsOffspringFeasible verifies the repair hook runs on new
+// offspring before they are evaluated
+func TestRepairerKeepsOffspringFeasible(t *testing.T) {
+	repairs := 0
+	algorithm := New(
+		WithPopulation(newBoundedPopulation(20, 10, 5)),
+		WithGenerations(10),
+		WithMutationRate(1),
+		WithRepairer(RepairFunc(func(c Chromosome, rng *rand.Rand) Chromosome {
+			repairs++
+			b := c.(*boundedChromosome)
+			if b.x > b.limit {
+				b.x = b.limit
+			}
+			return b
+		})),
+		WithRandomSeed(6),
+	)
+	if err := algorithm.Run(); err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	if repairs == 0 {
+		t.Fatal("Expected the repairer to be called")
+	}
+	for _, c := range algorithm.Population {
+		if c.(*boundedChromosome).Violation() != 0 {
+			t.Errorf("Expected repaired offspring to be feasible, got x=%d", c.(*boundedChromosome).x)
+		}
+	}
+}
+
+// TestCheckpointKeepsConstraintState verifies violations and penalty state
+// survive encoding
+func TestCheckpointKeepsConstraintState(t *testing.T) {
+	cp := &Checkpoint{
+		Population:     []Chromosome{&TSPChromosome{}},
+		Fitness:        []float64{1},
+		Violation:      []float64{2.5},
+		Evaluated:      []bool{true},
+		BestViolation:  0.5,
+		PenaltyWeight:  8,
+		FeasibleStreak: 3,
+	}
+	var buf bytes.Buffer
+	if err := EncodeCheckpoint(&buf, cp); err != nil {
+		t.Fatalf("Encode failed: %v", err)
+	}
+	got, err := DecodeCheckpoint(&buf)
+	if err != nil {
+		t.Fatalf("Decode failed: %v", err)
+	}
+	if got.Violation[0] != 2.5 || got.BestViolation != 0.5 || got.PenaltyWeight != 8 || got.FeasibleStreak != 3 {
+		t.Errorf("Constraint state lost in checkpoint: %+v", got)
+	}
+}
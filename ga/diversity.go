@@ -0,0 +1,171 @@
+package ga
+
+import (
+	"math"
+	"math/rand"
+)
+
+// Distancer is implemented by individuals that can measure how far their
+// genome is from another's. Chromosomes implement Distancer[Chromosome];
+// Engine individuals implement Distancer[T]. The distance must be
+// non-negative and symmetric, and 0 for identical genomes.
+//
+// When individuals implement it, GenerationStats reports the mean pairwise
+// distance of the population with WithDiversityMetrics, and niching methods
+// use it when no Distance function is set.
+type Distancer[T any] interface {
+	// Distance returns how far other's genome is from the receiver's.
+	Distance(other T) float64
+}
+
+// distanceSamples is the number of pairs whose distance is averaged for
+// GenerationStats.MeanDistance. Smaller populations measure every pair.
+const distanceSamples = 1000
+
+// WithDiversityMetrics makes every generation measure the genotypic
+// diversity of the population, GenerationStats.UniqueRatio and MeanDistance,
+// for the statistics callback, observers, terminators, rate schedules and
+// Result.History alike. The measures compare genomes pairwise, which can
+// cost more than the rest of the generation, so they are off by default and
+// NaN unless enabled. Diversity and FitnessEntropy are always measured.
+//
+// Example:
+//
+//	ga.WithDiversityMetrics(true)
+func WithDiversityMetrics(enabled bool) func(*GA) {
+	return func(ga *GA) {
+		ga.diversityMetrics = enabled
+	}
+}
+
+// measureDiversity fills in the diversity measures of stats. The genotypic
+// ones are NaN without WithDiversityMetrics.
+func (e *Engine[T]) measureDiversity(stats *GenerationStats, population []*member[T]) {
+	stats.FitnessEntropy = fitnessEntropy(population)
+	if !e.config.diversityMetrics {
+		stats.UniqueRatio = math.NaN()
+		stats.MeanDistance = math.NaN()
+		return
+	}
+	stats.UniqueRatio = e.uniqueRatio(population)
+	stats.MeanDistance = e.meanDistance(stats.Generation, population)
+}
+
+// genomeDistance returns the distance between the genomes of a and b, using
+// Distancer when the individual (or its Chromosome view) implements it. ok
+// is false if it implements neither.
+func (e *Engine[T]) genomeDistance(a, b *member[T]) (distance float64, ok bool) {
+	if d, ok := any(a.value).(Distancer[T]); ok {
+		return d.Distance(b.value), true
+	}
+	if d, ok := e.chromosomeOf(a.value, a.fitness).(Distancer[Chromosome]); ok {
+		return d.Distance(e.chromosomeOf(b.value, b.fitness)), true
+	}
+	return 0, false
+}
+
+// uniqueRatio returns the fraction of distinct genomes in population, as
+// judged by sameGenome. Identical genomes have identical fitness, so only
+// individuals with equal fitness are compared.
+func (e *Engine[T]) uniqueRatio(population []*member[T]) float64 {
+	groups := make(map[float64][]*member[T])
+	unique := 0
+	for _, m := range population {
+		group := groups[m.fitness]
+		duplicate := false
+		for _, other := range group {
+			if e.sameGenome(m, other) {
+				duplicate = true
+				break
+			}
+		}
+		if !duplicate {
+			groups[m.fitness] = append(group, m)
+			unique++
+		}
+	}
+	return float64(unique) / float64(len(population))
+}
+
+// meanDistance returns the mean distance between pairs of individuals, or
+// NaN if they do not implement Distancer. Above distanceSamples pairs it
+// averages a sample drawn from an RNG seeded with the generation, so the
+// measure is reproducible and the engine's own RNG is left untouched.
+func (e *Engine[T]) meanDistance(generation int, population []*member[T]) float64 {
+	n := len(population)
+	if n < 2 {
+		return 0
+	}
+
+	var sum float64
+	pairs := 0
+	add := func(i, j int) bool {
+		d, ok := e.genomeDistance(population[i], population[j])
+		sum += d
+		pairs++
+		return ok
+	}
+
+	if n*(n-1)/2 <= distanceSamples {
+		for i := 1; i < n; i++ {
+			for j := 0; j < i; j++ {
+				if !add(i, j) {
+					return math.NaN()
+				}
+			}
+		}
+	} else {
+		rng := rand.New(rand.NewSource(int64(generation)))
+		for pairs < distanceSamples {
+			i := rng.Intn(n)
+			j := rng.Intn(n - 1)
+			if j >= i {
+				j++
+			}
+			if !add(i, j) {
+				return math.NaN()
+			}
+		}
+	}
+	return sum / float64(pairs)
+}
+
+// fitnessEntropy returns the Shannon entropy of the finite fitness values
+// binned into ⌈√n⌉ equal-width bins between the lowest and highest, divided
+// by its maximum so it lies in [0, 1]. It is 0 when all finite values are
+// equal and 1 when they spread evenly over the range.
+func fitnessEntropy[T Individual[T]](population []*member[T]) float64 {
+	lowest, highest := math.Inf(1), math.Inf(-1)
+	finite := 0
+	for _, m := range population {
+		if !math.IsInf(m.fitness, 0) && !math.IsNaN(m.fitness) {
+			lowest = math.Min(lowest, m.fitness)
+			highest = math.Max(highest, m.fitness)
+			finite++
+		}
+	}
+	if finite < 2 || lowest == highest {
+		return 0
+	}
+
+	bins := int(math.Ceil(math.Sqrt(float64(finite))))
+	counts := make([]int, bins)
+	for _, m := range population {
+		if !math.IsInf(m.fitness, 0) && !math.IsNaN(m.fitness) {
+			bin := int((m.fitness - lowest) / (highest - lowest) * float64(bins))
+			if bin >= bins {
+				bin = bins - 1
+			}
+			counts[bin]++
+		}
+	}
+
+	var entropy float64
+	for _, count := range counts {
+		if count > 0 {
+			p := float64(count) / float64(finite)
+			entropy -= p * math.Log(p)
+		}
+	}
+	return entropy / math.Log(float64(bins))
+}
@@ -0,0 +1,194 @@
+package ga
+
+import (
+	"context"
+	"math"
+	"strings"
+	"testing"
+)
+
+// TestTSPDistanceCountsSharedEdges verifies the edge distance ignores
+// rotation and direction
+func TestTSPDistanceCountsSharedEdges(t *testing.T) {
+	route := newTSPPopulation(1, 6, 1)[0].(*TSPChromosome)
+
+	rotated := route.Clone().(*TSPChromosome)
+	rotated.Route = append(rotated.Route[2:], rotated.Route[:2]...)
+	reversed := route.Clone().(*TSPChromosome)
+	for i, j := 0, len(reversed.Route)-1; i < j; i, j = i+1, j-1 {
+		reversed.Route[i], reversed.Route[j] = reversed.Route[j], reversed.Route[i]
+	}
+	if d := route.Distance(rotated); d != 0 {
+		t.Errorf("Expected distance 0 to a rotation, got %f", d)
+	}
+	if d := route.Distance(reversed); d != 0 {
+		t.Errorf("Expected distance 0 to the reversed tour, got %f", d)
+	}
+
+	// Swapping two adjacent cities changes two of the six edges
+	swapped := route.Clone().(*TSPChromosome)
+	swapped.Route[1], swapped.Route[2] = swapped.Route[2], swapped.Route[1]
+	if d := route.Distance(swapped); math.Abs(d-2.0/6) > 1e-12 {
+		t.Errorf("Expected distance 2/6, got %f", d)
+	}
+	if route.Distance(swapped) != swapped.Distance(route) {
+		t.Error("Expected a symmetric distance")
+	}
+}
+
+// TestDiversityStats verifies the genotypic measures recorded in History
+func TestDiversityStats(t *testing.T) {
+	population := newTSPPopulation(4, 8, 1)
+	population[1] = population[0].Clone()
+
+	var reported []GenerationStats
+	algorithm := New(
+		WithPopulation(population),
+		WithGenerations(3),
+		WithRandomSeed(1),
+		WithDiversityMetrics(true),
+		WithStatsCallback(func(stats GenerationStats) {
+			reported = append(reported, stats)
+		}),
+	)
+	result, err := algorithm.RunContext(context.Background())
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+
+	first := result.History[0]
+	if first.UniqueRatio != 0.75 {
+		t.Errorf("Expected unique ratio 0.75 with one duplicate, got %f", first.UniqueRatio)
+	}
+	if first.MeanDistance <= 0 || first.MeanDistance > 1 {
+		t.Errorf("Expected mean edge distance in (0, 1], got %f", first.MeanDistance)
+	}
+
+	if len(reported) != len(result.History) {
+		t.Fatalf("Expected %d stats callbacks, got %d", len(result.History), len(reported))
+	}
+	for i := range reported {
+		if reported[i] != result.History[i] {
+			t.Errorf("Callback %d differs from history: %+v vs %+v", i, reported[i], result.History[i])
+		}
+	}
+}
+
+// TestMeanDistanceWithoutDistancer verifies the measure is NaN when
+// chromosomes cannot be compared
+func TestMeanDistanceWithoutDistancer(t *testing.T) {
+	result, err := New(WithPopulation(mockPopulation(1, 2, 3)), WithGenerations(1)).RunContext(context.Background())
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	if !math.IsNaN(result.History[0].MeanDistance) {
+		t.Errorf("Expected NaN mean distance, got %f", result.History[0].MeanDistance)
+	}
+}
+
+// TestWithDiversityMetrics verifies the genotypic measures are only taken
+// when enabled, whoever reads them
+func TestWithDiversityMetrics(t *testing.T) {
+	tests := []struct {
+		name     string
+		opts     []func(*GA)
+		measured bool
+	}{
+		{"default", nil, false},
+		{"stats callback", []func(*GA){WithStatsCallback(func(GenerationStats) {})}, false},
+		{"on", []func(*GA){WithDiversityMetrics(true)}, true},
+		{"on then off", []func(*GA){WithDiversityMetrics(true), WithDiversityMetrics(false)}, false},
+	}
+	for _, tt := range tests {
+		opts := append([]func(*GA){WithPopulation(newTSPPopulation(4, 8, 1)), WithGenerations(2)}, tt.opts...)
+		result, err := New(opts...).RunContext(context.Background())
+		if err != nil {
+			t.Fatalf("%s: run failed: %v", tt.name, err)
+		}
+		for _, stats := range result.History {
+			if measured := !math.IsNaN(stats.UniqueRatio) && !math.IsNaN(stats.MeanDistance); measured != tt.measured {
+				t.Errorf("%s: generation %d measured = %v, expected %v", tt.name, stats.Generation, measured, tt.measured)
+			}
+			if math.IsNaN(stats.FitnessEntropy) {
+				t.Errorf("%s: expected fitness entropy to always be measured", tt.name)
+			}
+		}
+	}
+
+	floor := Any(Plateau{Generations: 10}, DiversityFloor{Min: 0.1})
+	err := New(WithPopulation(mockPopulation(1, 2)), WithTerminator(floor)).Validate()
+	if err == nil || !strings.Contains(err.Error(), "WithDiversityMetrics") {
+		t.Errorf("Expected DiversityFloor to require WithDiversityMetrics, got %v", err)
+	}
+	custom := DiversityFloor{Min: 0.1, Measure: func(stats GenerationStats) float64 { return stats.Diversity }}
+	if err := New(WithPopulation(mockPopulation(1, 2)), WithTerminator(custom)).Validate(); err != nil {
+		t.Errorf("Expected a custom Measure not to need diversity metrics, got %v", err)
+	}
+	if err := New(WithPopulation(mockPopulation(1, 2)), WithTerminator(floor), WithDiversityMetrics(true)).Validate(); err != nil {
+		t.Errorf("Expected DiversityFloor with diversity metrics to be valid, got %v", err)
+	}
+}
+
+// TestMeanDistanceSampling verifies large populations are sampled
+// reproducibly close to the exact mean
+func TestMeanDistanceSampling(t *testing.T) {
+	values := newTSPPopulation(60, 10, 1)
+	population := make([]*member[chromosomeIndividual], len(values))
+	for i, c := range values {
+		population[i] = &member[chromosomeIndividual]{value: chromosomeIndividual{c}}
+	}
+	engine := New(WithPopulation(values)).engine()
+
+	var exact float64
+	for i := range values {
+		for j := 0; j < i; j++ {
+			exact += values[i].(*TSPChromosome).Distance(values[j])
+		}
+	}
+	exact /= float64(len(values) * (len(values) - 1) / 2)
+
+	sampled := engine.meanDistance(4, population)
+	if sampled != engine.meanDistance(4, population) {
+		t.Error("Expected the same sample for the same generation")
+	}
+	if math.Abs(sampled-exact) > 0.02 {
+		t.Errorf("Expected a sampled mean near %f, got %f", exact, sampled)
+	}
+}
+
+// TestFitnessEntropy verifies the normalized histogram entropy
+func TestFitnessEntropy(t *testing.T) {
+	members := func(fitness ...float64) []*member[chromosomeIndividual] {
+		population := make([]*member[chromosomeIndividual], len(fitness))
+		for i, f := range fitness {
+			population[i] = &member[chromosomeIndividual]{fitness: f, evaluated: true}
+		}
+		return population
+	}
+
+	if h := fitnessEntropy(members(2, 2, 2, 2)); h != 0 {
+		t.Errorf("Expected entropy 0 for equal fitness, got %f", h)
+	}
+	// Four values in two bins, two in each
+	if h := fitnessEntropy(members(0, 0.1, 0.9, 1, math.Inf(1))); math.Abs(h-1) > 1e-12 {
+		t.Errorf("Expected entropy 1 for an even spread, got %f", h)
+	}
+	if h := fitnessEntropy(members(0, 1, 1, 1)); h <= 0 || h >= 1 {
+		t.Errorf("Expected entropy strictly between 0 and 1, got %f", h)
+	}
+}
+
+// TestNichingFallsBackToDistancer verifies a nil Distance uses the
+// chromosomes' Distancer
+func TestNichingFallsBackToDistancer(t *testing.T) {
+	population := newTSPPopulation(6, 8, 1)
+	population[1] = population[0].Clone()
+
+	peaks, err := NichePeaks(population, nil, 0.01)
+	if err != nil {
+		t.Fatalf("NichePeaks failed: %v", err)
+	}
+	if len(peaks) != 5 {
+		t.Errorf("Expected the duplicate route to share a niche, got %d peaks", len(peaks))
+	}
+}
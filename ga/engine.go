@@ -0,0 +1,709 @@
+package ga
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+	"time"
+)
+
+// Individual is the type-safe counterpart of Chromosome used by Engine. The
+// type parameter is the implementing type itself, so Crossover receives and
+// returns the concrete type and never needs a type assertion:
+//
+//	type Route struct{ Cities []City }
+//
+//	func (r *Route) Fitness() float64          { ... }
+//	func (r *Route) Crossover(other *Route) *Route { ... }
+//	func (r *Route) Mutate()                   { ... }
+//	func (r *Route) Clone() *Route             { ... }
+type Individual[T any] interface {
+	// Fitness returns the quality of this solution. Higher values are better.
+	Fitness() float64
+
+	// Crossover combines this individual with another to create a new offspring.
+	Crossover(other T) T
+
+	// Mutate introduces a random change to this individual.
+	Mutate()
+
+	// Clone creates a deep copy of this individual.
+	Clone() T
+}
+
+// RandomIndividual is the Individual counterpart of RandomChromosome. When T
+// implements it, the engine passes its own RNG to the genetic operators.
+type RandomIndividual[T any] interface {
+	// CrossoverRand combines this individual with another using rng for all
+	// random decisions.
+	CrossoverRand(other T, rng *rand.Rand) T
+
+	// MutateRand introduces a random change using rng for all random decisions.
+	MutateRand(rng *rand.Rand)
+}
+
+// cancelCheckInterval is the number of offspring bred between context checks
+// while filling the next generation, so very large populations still react
+// promptly to cancellation.
+const cancelCheckInterval = 1024
+
+// Stats describes what happened during a run.
+type Stats struct {
+	// Generations is the number of generations that were evaluated.
+	Generations int
+
+	// Evaluations is the number of Fitness calls made by the engine. Each
+	// individual is scored once; elites and unmutated clones reuse the score.
+	Evaluations int
+
+	// StopReason records why the run ended.
+	StopReason StopReason
+
+	// Termination describes the criterion that ended the run when
+	// StopReason is StopTerminated.
+	Termination string
+
+	// Restarts is the number of times the run restarted on stagnation
+	// (see WithRestart).
+	Restarts int
+
+	// Duration is the wall-clock time the run took.
+	Duration time.Duration
+
+	// History holds one entry per evaluated generation, in order.
+	History []GenerationStats
+}
+
+// Engine is a type-parameterized genetic algorithm. It runs the same
+// evolutionary loop as GA, but on a population of a concrete type T, so
+// crossover is type-safe and Best returns T without an assertion. GA is a
+// thin adapter that runs an Engine over its []Chromosome population.
+//
+// Engine is configured with the same option functions as GA; WithPopulation
+// is ignored because the population is passed to NewEngine directly. Hooks
+// typed on Chromosome, such as the progress callback, receive a Chromosome
+// view of the individual; use IndividualOf to recover the typed value.
+type Engine[T Individual[T]] struct {
+	// Population is the current population. It is updated as the run
+	// progresses and holds the last generation bred once the run ends.
+	Population []T
+
+	config  *GA
+	best    T
+	hasBest bool
+
+	// hallOfFame holds clones of the best distinct members of the run, best
+	// first, when WithHallOfFame is set.
+	hallOfFame []*member[T]
+
+	// resumeState, when set, makes the next RunContext continue a run
+	// restored from a checkpoint instead of starting over.
+	resumeState *runState[T]
+
+	// toChromosome and fromChromosome convert between T and Chromosome for
+	// Chromosome-typed hooks. GA installs conversions that unwrap and wrap
+	// its adapter; standalone engines use the defaults in chromosomeOf and
+	// individualFrom.
+	toChromosome   func(T) Chromosome
+	fromChromosome func(Chromosome) (T, bool)
+}
+
+// NewEngine creates an engine for the given population, configured with the
+// same options and defaults as New.
+//
+// Example:
+//
+//	engine := ga.NewEngine(routes,
+//	    ga.WithGenerations(200),
+//	    ga.WithRandomSeed(42),
+//	)
+//	err := engine.Run()
+//	best := engine.Best() // *Route, no type assertion
+func NewEngine[T Individual[T]](population []T, options ...func(*GA)) *Engine[T] {
+	return &Engine[T]{
+		Population: population,
+		config:     New(options...),
+	}
+}
+
+// Validate checks if the engine configuration is valid and returns an error
+// if any issues are found. It applies the same checks as GA.Validate.
+func (e *Engine[T]) Validate() error {
+	if err := validatePopulationSize(e.config.populationSize(len(e.Population))); err != nil {
+		return err
+	}
+	if err := e.config.validateSettings(); err != nil {
+		return err
+	}
+	if e.fromChromosome == nil && e.config.checkpointer != nil {
+		return fmt.Errorf("WithCheckpointer is not supported by a standalone Engine[%T]: checkpoints are saved with a Codec, and only a GA converts its individuals to and from Chromosome for one; checkpoint through GA instead", *new(T))
+	}
+	if e.fromChromosome == nil && e.config.initializer != nil {
+		return fmt.Errorf("WithInitializer is not supported by Engine: Initializer creates Chromosomes, not %T", *new(T))
+	}
+	if e.fromChromosome == nil && e.config.restart != nil && e.config.restart.Initializer != nil {
+		return fmt.Errorf("Restart.Initializer is not supported by Engine: Initializer creates Chromosomes, not %T", *new(T))
+	}
+	for i, value := range e.Population {
+		if err := e.validateIndividual(i, value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validateIndividual checks that individual i supports the configured
+// mutation mode and niching method.
+func (e *Engine[T]) validateIndividual(i int, value T) error {
+	if err := e.config.validateMutationMode(i, unwrapIndividual(value)); err != nil {
+		return err
+	}
+	_, typed := any(value).(Distancer[T])
+	return e.config.validateDistancer(i, unwrapIndividual(value), typed)
+}
+
+// Run executes the genetic algorithm. See GA.Run for a description of the
+// loop and the thread-safety rules, which apply unchanged.
+func (e *Engine[T]) Run() error {
+	_, err := e.RunContext(context.Background())
+	return err
+}
+
+// RunContext executes the genetic algorithm like Run, but stops early when ctx
+// is cancelled or its deadline expires. On cancellation Best still returns
+// the best individual found so far and the error wraps ctx.Err().
+func (e *Engine[T]) RunContext(ctx context.Context) (Stats, error) {
+	// Validate configuration before running
+	if err := e.Validate(); err != nil {
+		return Stats{}, fmt.Errorf("invalid GA configuration: %w", err)
+	}
+
+	state := e.resumeState
+	e.resumeState = nil
+	if state == nil {
+		state = e.newRunState()
+	}
+	return e.run(ctx, state, e.config.Generations)
+}
+
+// runState is everything the engine needs to continue a run from the start
+// of a generation. It is what checkpoints capture and restore.
+type runState[T Individual[T]] struct {
+	// generation is the next generation to evaluate.
+	generation int
+
+	// population is the population about to be evaluated.
+	population []*member[T]
+
+	bestFitness                   float64
+	bestViolation                 float64
+	lastBestFitness               float64
+	generationsWithoutImprovement int
+
+	// mutationRate and crossoverRate are the effective rates, which
+	// differ from the configured ones only with a RateSchedule.
+	mutationRate  float64
+	crossoverRate float64
+
+	// penaltyWeight and the streak counters drive WithAdaptivePenalty.
+	penaltyWeight    float64
+	feasibleStreak   int
+	infeasibleStreak int
+
+	stats Stats
+
+	// started records that observers have been told the run started.
+	started bool
+}
+
+// newRunState prepares a fresh run over e.Population.
+func (e *Engine[T]) newRunState() *runState[T] {
+	state := &runState[T]{
+		bestFitness:     math.Inf(-1),
+		lastBestFitness: math.Inf(-1),
+		mutationRate:    e.config.MutationRate,
+		crossoverRate:   e.config.CrossoverRate,
+	}
+	if e.hasBest {
+		state.bestFitness = e.best.Fitness()
+		state.bestViolation = violationOf(unwrapIndividual(e.best))
+		state.stats.Evaluations++
+	}
+	if e.config.penalty != nil {
+		state.penaltyWeight = e.config.penalty.Initial
+	}
+	e.hallOfFame = nil
+
+	// Wrap the population so every individual carries its cached fitness.
+	state.population = make([]*member[T], len(e.Population))
+	for j, value := range e.Population {
+		state.population[j] = &member[T]{value: value}
+	}
+	return state
+}
+
+// run executes generations from state until a stop condition is met or the
+// generation counter reaches until. In the latter case, if until is below
+// the configured number of generations, the run is paused: the returned
+// stats have StopReason StopNone and state can be passed to run again.
+func (e *Engine[T]) run(ctx context.Context, state *runState[T], until int) (Stats, error) {
+	cfg := e.config
+	stats := &state.stats
+
+	start := time.Now()
+	elapsed := stats.Duration
+	stop := func(reason StopReason, err error) (Stats, error) {
+		stats.Duration = elapsed + time.Since(start)
+		return e.end(state, reason, err), err
+	}
+	cancelled := func(generation int) (Stats, error) {
+		return stop(StopCancelled, fmt.Errorf("run cancelled at generation %d: %w", generation, ctx.Err()))
+	}
+
+	population := state.population
+	selectable := make([]Chromosome, len(population))
+
+	if !state.started {
+		state.started = true
+		e.notify(RunStarted, state, state.generation, nil)
+	}
+
+	for ; state.generation < cfg.Generations && state.generation < until; state.generation++ {
+		i := state.generation
+		if ctx.Err() != nil {
+			return cancelled(i)
+		}
+		e.notify(GenerationStarted, state, i, nil)
+
+		// Score individuals that have no cached fitness and sort the
+		// population by fitness. Selection works on the members so
+		// Fitness is not called again.
+		stats.Evaluations += e.evaluate(population)
+		success := successRate(population)
+		e.applyPenalty(population, state.penaltyWeight)
+		sortByFitness(population)
+		if len(selectable) != len(population) {
+			// Restarts may have grown the population
+			selectable = make([]Chromosome, len(population))
+		}
+		e.Population = make([]T, len(population))
+		for j, m := range population {
+			e.Population[j] = m.value
+			selectable[j] = m
+		}
+		e.adaptPenalty(state, population[0])
+
+		// Update the best individual. Constrained problems prefer
+		// feasible solutions by Deb's rules.
+		top := e.bestMember(population)
+		currentBestFitness := top.fitness
+		improved := !e.hasBest || ranksAbove(top.fitness, top.violation, state.bestFitness, state.bestViolation)
+		if improved {
+			e.best = top.value.Clone()
+			e.hasBest = true
+			state.bestFitness = top.fitness
+			state.bestViolation = top.violation
+		}
+		e.updateHallOfFame(population)
+		stats.Generations = i + 1
+		generation := generationStats(i, population)
+		e.measureDiversity(&generation, population)
+		e.updateRates(state, &generation, success)
+		stats.History = append(stats.History, generation)
+		if improved {
+			e.notify(NewBest, state, i, nil)
+		}
+
+		// Stop once the target fitness is reached
+		if cfg.hasTargetFitness && state.bestFitness >= cfg.targetFitness && state.bestViolation == 0 {
+			e.reportProgress(state, generation)
+			return stop(StopTargetReached, nil)
+		}
+
+		// Stop once the configured termination criterion is met
+		if cfg.terminator != nil {
+			done, reason := cfg.terminator.Terminate(TerminationContext{
+				Generation:    i,
+				Stats:         generation,
+				History:       stats.History,
+				BestFitness:   state.bestFitness,
+				BestViolation: state.bestViolation,
+				Evaluations:   stats.Evaluations,
+				Elapsed:       elapsed + time.Since(start),
+			})
+			if done {
+				stats.Termination = reason
+				e.reportProgress(state, generation)
+				return stop(StopTerminated, nil)
+			}
+		}
+
+		// Check for convergence, which ends the run or, with WithRestart,
+		// restarts it
+		restart := false
+		if cfg.convergenceGenerations > 0 {
+			improvement := currentBestFitness - state.lastBestFitness
+			if improvement > cfg.convergenceThreshold {
+				// Significant improvement, reset counter
+				state.generationsWithoutImprovement = 0
+			} else {
+				// No improvement, increment counter
+				state.generationsWithoutImprovement++
+				if state.generationsWithoutImprovement >= cfg.convergenceGenerations {
+					e.notify(Converged, state, i, nil)
+					if !e.canRestart(state) {
+						// Converged - call callback one last time and exit
+						e.reportProgress(state, generation)
+						return stop(StopConverged, nil)
+					}
+					restart = true
+				}
+			}
+			state.lastBestFitness = currentBestFitness
+		}
+
+		// Call progress callback if provided
+		e.reportProgress(state, generation)
+
+		// Create the next generation, either wholesale or one brood at a
+		// time in steady-state mode, or restart from the best individuals.
+		e.setGeneration(i)
+		var nextGeneration []*member[T]
+		var err error
+		if restart {
+			nextGeneration, err = e.restartPopulation(population)
+			state.generationsWithoutImprovement = 0
+			state.lastBestFitness = math.Inf(-1)
+			stats.Restarts++
+		} else if cfg.replacement != nil {
+			nextGeneration, err = e.steadyState(ctx, population, state)
+		} else {
+			nextGeneration, err = e.generational(ctx, population, selectable, state)
+		}
+		if err != nil {
+			if ctx.Err() != nil && errors.Is(err, ctx.Err()) {
+				return cancelled(i)
+			}
+			return stop(StopNone, err)
+		}
+
+		population = nextGeneration
+		state.population = population
+		e.Population = make([]T, len(population))
+		for j, m := range population {
+			e.Population[j] = m.value
+		}
+		if restart {
+			e.notify(Restarted, state, i, nil)
+		}
+
+		// Persist the state needed to resume at the next generation
+		if cfg.checkpointer != nil && (i+1)%cfg.checkpointInterval == 0 {
+			stats.Duration = elapsed + time.Since(start)
+			if err := e.saveCheckpoint(state, i+1); err != nil {
+				return stop(StopNone, fmt.Errorf("checkpoint at generation %d: %w", i+1, err))
+			}
+		}
+	}
+
+	if state.generation < cfg.Generations {
+		stats.Duration = elapsed + time.Since(start)
+		return *stats, nil
+	}
+	return stop(StopMaxGenerations, nil)
+}
+
+// end records why the run stopped and tells observers it has ended. It also
+// ends runs paused between generations, such as islands stopped by another
+// island.
+func (e *Engine[T]) end(state *runState[T], reason StopReason, err error) Stats {
+	state.stats.StopReason = reason
+	e.notify(RunEnded, state, state.stats.Generations-1, err)
+	return state.stats
+}
+
+// Best returns the best individual found during the engine's execution and
+// the zero value of T if Run has not been called yet.
+func (e *Engine[T]) Best() T {
+	return e.best
+}
+
+// generational breeds a complete replacement for population, which must be
+// sorted by descending fitness. It returns ctx.Err() if ctx is cancelled
+// while breeding, leaving population intact.
+func (e *Engine[T]) generational(ctx context.Context, population []*member[T], selectable []Chromosome, state *runState[T]) ([]*member[T], error) {
+	e.prepareSelector(selectable)
+	nextGeneration := make([]*member[T], len(population))
+	nextIndex := 0
+
+	// Carry over clones of the elites, which keep their cached fitness
+	for _, elite := range e.elites(population) {
+		nextGeneration[nextIndex] = elite.clone()
+		nextIndex++
+	}
+
+	// Fill the rest of the population
+	// NOTE: No mutex needed here because each engine has its own RNG.
+	// The rng is not shared across goroutines, making this safe for
+	// concurrent execution of multiple engines.
+	for nextIndex < len(population) {
+		// Check for cancellation between batches of offspring.
+		if nextIndex%cancelCheckInterval == 0 && ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+
+		offspring, _, _, err := e.breed(selectable, state)
+		if err != nil {
+			return nil, err
+		}
+		nextGeneration[nextIndex] = offspring
+		nextIndex++
+	}
+	return nextGeneration, nil
+}
+
+// elites returns the members of population, which must be sorted by
+// descending fitness, that survive into the next generation.
+func (e *Engine[T]) elites(population []*member[T]) []*member[T] {
+	cfg := e.config
+	if !cfg.Elitism {
+		return nil
+	}
+	k := 1
+	switch {
+	case cfg.eliteFraction > 0:
+		k = int(math.Round(cfg.eliteFraction * float64(len(population))))
+		if k < 1 {
+			k = 1
+		}
+	case cfg.eliteCount > 0:
+		k = cfg.eliteCount
+	}
+	if k > len(population) {
+		k = len(population)
+	}
+	if !cfg.distinctElites {
+		return population[:k]
+	}
+
+	elites := make([]*member[T], 0, k)
+	for _, candidate := range population {
+		if len(elites) == k {
+			break
+		}
+		duplicate := false
+		for _, elite := range elites {
+			if e.sameGenome(candidate, elite) {
+				duplicate = true
+				break
+			}
+		}
+		if !duplicate {
+			elites = append(elites, candidate)
+		}
+	}
+	return elites
+}
+
+// sameGenome reports whether a and b hold the same genome, using Hasher and
+// Equaler when the individual (or its Chromosome view) implements them and
+// comparing cached fitness otherwise.
+func (e *Engine[T]) sameGenome(a, b *member[T]) bool {
+	hashA, hashed := e.hashOf(a)
+	if hashed {
+		if hashB, _ := e.hashOf(b); hashA != hashB {
+			return false
+		}
+	}
+	if eq, ok := any(a.value).(Equaler[T]); ok {
+		return eq.Equal(b.value)
+	}
+	if e.toChromosome != nil {
+		if eq, ok := e.toChromosome(a.value).(Equaler[Chromosome]); ok {
+			return eq.Equal(e.toChromosome(b.value))
+		}
+	}
+	if hashed {
+		return true
+	}
+	return a.fitness == b.fitness
+}
+
+// hashOf returns the Hasher hash of m's individual (or its Chromosome view),
+// and false if it implements neither.
+func (e *Engine[T]) hashOf(m *member[T]) (uint64, bool) {
+	if h, ok := any(m.value).(Hasher); ok {
+		return h.Hash(), true
+	}
+	if e.toChromosome != nil {
+		if h, ok := e.toChromosome(m.value).(Hasher); ok {
+			return h.Hash(), true
+		}
+	}
+	return 0, false
+}
+
+// breed selects two parents from selectable and produces one offspring by
+// crossover or cloning followed by optional mutation, at the effective rates
+// in state.
+func (e *Engine[T]) breed(selectable []Chromosome, state *runState[T]) (offspring, parent1, parent2 *member[T], err error) {
+	cfg := e.config
+
+	// Select parents using the engine's thread-safe RNG
+	parent1, parent2, err = e.selectParents(selectable)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	// Crossover produces a new, unevaluated genome; a clone keeps
+	// its parent's cached fitness.
+	if cfg.rng.Float64() < state.crossoverRate {
+		offspring = parent1.crossover(parent2, cfg.rng)
+	} else {
+		// If no crossover, clone the first parent
+		offspring = parent1.clone()
+	}
+
+	// Mutation invalidates the cached fitness
+	if cfg.mutationMode == PerGene {
+		offspring.mutateGenes(state.mutationRate, cfg.rng)
+	} else if cfg.rng.Float64() < state.mutationRate {
+		offspring.mutate(cfg.rng)
+	}
+
+	// Repair new genomes before they are evaluated
+	if cfg.repairer != nil && !offspring.evaluated {
+		if offspring, err = e.repair(offspring); err != nil {
+			return nil, nil, nil, err
+		}
+	}
+
+	// Remember the parents' fitness to measure breeding success
+	if !offspring.evaluated {
+		offspring.bred = true
+		offspring.parentFitness = math.Max(parent1.fitness, parent2.fitness)
+	}
+	return offspring, parent1, parent2, nil
+}
+
+// sortByFitness sorts members from best to worst by cached fitness, using
+// Deb's feasibility rules for constrained problems.
+func sortByFitness[T Individual[T]](population []*member[T]) {
+	sort.Slice(population, func(i, j int) bool {
+		return Fitter(population[i], population[j])
+	})
+}
+
+// bestMember returns the best member of a sorted population by Deb's rules
+// on the unpenalized fitness. Without an adaptive penalty that is simply the
+// first member.
+func (e *Engine[T]) bestMember(population []*member[T]) *member[T] {
+	best := population[0]
+	if e.config.penalty == nil {
+		return best
+	}
+	for _, m := range population[1:] {
+		if ranksAbove(m.fitness, m.violation, best.fitness, best.violation) {
+			best = m
+		}
+	}
+	return best
+}
+
+// setGeneration tells generation-aware operators which generation is about
+// to be bred.
+func (e *Engine[T]) setGeneration(generation int) {
+	cfg := e.config
+	for _, operator := range []any{cfg.selector, cfg.replacement} {
+		if g, ok := operator.(GenerationAware); ok {
+			g.SetGeneration(generation, cfg.Generations)
+		}
+	}
+}
+
+// prepareSelector lets a PopulationAware selector precompute its data for the
+// population about to be passed to Select.
+func (e *Engine[T]) prepareSelector(selectable []Chromosome) {
+	if p, ok := e.config.selector.(PopulationAware); ok {
+		p.Prepare(selectable)
+	}
+}
+
+// selectParents runs the configured selector and maps the chosen chromosomes
+// back to population members.
+func (e *Engine[T]) selectParents(selectable []Chromosome) (*member[T], *member[T], error) {
+	parents := e.config.selector.Select(selectable, e.config.rng)
+	if len(parents) < 2 {
+		return nil, nil, fmt.Errorf("selector returned %d parents, need 2", len(parents))
+	}
+	parent1, err := e.memberOf(parents[0])
+	if err != nil {
+		return nil, nil, err
+	}
+	parent2, err := e.memberOf(parents[1])
+	if err != nil {
+		return nil, nil, err
+	}
+	return parent1, parent2, nil
+}
+
+// memberOf returns c as a population member. Chromosomes that are not
+// members (e.g. a custom selector built its own) are converted if possible
+// and start unevaluated.
+func (e *Engine[T]) memberOf(c Chromosome) (*member[T], error) {
+	if m, ok := c.(*member[T]); ok {
+		return m, nil
+	}
+	if value, ok := e.individualFrom(c); ok {
+		return &member[T]{value: value}, nil
+	}
+	return nil, fmt.Errorf("selector returned %T, which is not a member of the population", c)
+}
+
+// chromosomeOf presents value to Chromosome-typed hooks.
+func (e *Engine[T]) chromosomeOf(value T, fitness float64) Chromosome {
+	if e.toChromosome != nil {
+		return e.toChromosome(value)
+	}
+	if c, ok := any(value).(Chromosome); ok {
+		return c
+	}
+	return &member[T]{value: value, fitness: fitness, evaluated: true}
+}
+
+// individualFrom converts a Chromosome produced by a Chromosome-typed hook
+// back to T.
+func (e *Engine[T]) individualFrom(c Chromosome) (T, bool) {
+	if e.fromChromosome != nil {
+		return e.fromChromosome(c)
+	}
+	value, ok := any(c).(T)
+	return value, ok
+}
+
+// reportProgress calls the progress callback, if any, with the best
+// individual found so far, the statistics callback with the summary of the
+// generation, and then tells observers the generation has ended.
+func (e *Engine[T]) reportProgress(state *runState[T], generation GenerationStats) {
+	if e.config.progressCallback != nil {
+		e.config.progressCallback(generation.Generation, e.chromosomeOf(e.best, state.bestFitness))
+	}
+	if e.config.statsCallback != nil {
+		e.config.statsCallback(generation)
+	}
+	e.notify(GenerationEnded, state, generation.Generation, nil)
+}
+
+// IndividualOf returns the typed individual behind a Chromosome handed out by
+// an Engine[T], such as the population elements passed to a Selector or the
+// best individual passed to a progress callback.
+func IndividualOf[T Individual[T]](c Chromosome) (T, bool) {
+	if m, ok := c.(*member[T]); ok {
+		return m.value, true
+	}
+	value, ok := any(c).(T)
+	return value, ok
+}
@@ -0,0 +1,212 @@
+package ga
+
+import (
+	"math/rand"
+	"strings"
+	"testing"
+)
+
+// bitString is a typed One-Max individual used to exercise Engine.
+type bitString struct {
+	bits []bool
+}
+
+func (b *bitString) Fitness() float64 {
+	score := 0
+	for _, bit := range b.bits {
+		if bit {
+			score++
+		}
+	}
+	return float64(score)
+}
+
+func (b *bitString) Crossover(other *bitString) *bitString {
+	return b.CrossoverRand(other, rand.New(rand.NewSource(0)))
+}
+
+func (b *bitString) CrossoverRand(other *bitString, rng *rand.Rand) *bitString {
+	point := rng.Intn(len(b.bits))
+	child := make([]bool, len(b.bits))
+	copy(child[:point], b.bits[:point])
+	copy(child[point:], other.bits[point:])
+	return &bitString{bits: child}
+}
+
+func (b *bitString) Mutate() {
+	b.MutateRand(rand.New(rand.NewSource(0)))
+}
+
+func (b *bitString) MutateRand(rng *rand.Rand) {
+	i := rng.Intn(len(b.bits))
+	b.bits[i] = !b.bits[i]
+}
+
+func (b *bitString) Clone() *bitString {
+	bits := make([]bool, len(b.bits))
+	copy(bits, b.bits)
+	return &bitString{bits: bits}
+}
+
+func newBitStrings(size, length int, seed int64) []*bitString {
+	rng := rand.New(rand.NewSource(seed))
+	population := make([]*bitString, size)
+	for i := range population {
+		bits := make([]bool, length)
+		for j := range bits {
+			bits[j] = rng.Float64() < 0.3
+		}
+		population[i] = &bitString{bits: bits}
+	}
+	return population
+}
+
+// TestEngineBestIsTyped verifies the generic engine evolves a typed population
+// and returns a typed best individual
+func TestEngineBestIsTyped(t *testing.T) {
+	population := newBitStrings(40, 20, 1)
+	initialBest := 0.0
+	for _, b := range population {
+		if b.Fitness() > initialBest {
+			initialBest = b.Fitness()
+		}
+	}
+
+	engine := NewEngine(population,
+		WithGenerations(60),
+		WithMutationRate(0.3),
+		WithRandomSeed(42),
+	)
+	if err := engine.Run(); err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+
+	var best *bitString = engine.Best()
+	if best == nil {
+		t.Fatal("Expected best individual to be set")
+	}
+	if best.Fitness() <= initialBest {
+		t.Errorf("Expected improvement over initial best %f, got %f", initialBest, best.Fitness())
+	}
+}
+
+// TestEngineReproducibleWithSeed verifies seeded engines evolve identically
+func TestEngineReproducibleWithSeed(t *testing.T) {
+	run := func() *bitString {
+		engine := NewEngine(newBitStrings(30, 16, 3),
+			WithGenerations(25),
+			WithMutationRate(0.2),
+			WithRandomSeed(7),
+		)
+		if err := engine.Run(); err != nil {
+			t.Fatalf("Run failed: %v", err)
+		}
+		return engine.Best()
+	}
+
+	best1, best2 := run(), run()
+	for i := range best1.bits {
+		if best1.bits[i] != best2.bits[i] {
+			t.Fatalf("Best individuals differ at bit %d", i)
+		}
+	}
+}
+
+// TestEngineProgressCallbackIndividualOf verifies Chromosome-typed hooks can
+// recover the typed individual
+func TestEngineProgressCallbackIndividualOf(t *testing.T) {
+	calls := 0
+	engine := NewEngine(newBitStrings(10, 8, 5),
+		WithGenerations(5),
+		WithRandomSeed(1),
+		WithProgressCallback(func(generation int, best Chromosome) {
+			calls++
+			typed, ok := IndividualOf[*bitString](best)
+			if !ok {
+				t.Fatalf("Expected *bitString behind %T", best)
+			}
+			if typed.Fitness() != best.Fitness() {
+				t.Errorf("Expected matching fitness, got %f and %f", typed.Fitness(), best.Fitness())
+			}
+		}),
+	)
+	if err := engine.Run(); err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	if calls != 5 {
+		t.Errorf("Expected 5 callbacks, got %d", calls)
+	}
+}
+
+// TestEngineValidate verifies the engine applies the GA validation rules
+func TestEngineValidate(t *testing.T) {
+	if err := NewEngine([]*bitString{}).Validate(); err == nil {
+		t.Error("Expected error for empty population, got nil")
+	}
+	if err := NewEngine(newBitStrings(2, 4, 1), WithMutationRate(2)).Validate(); err == nil {
+		t.Error("Expected error for invalid mutation rate, got nil")
+	}
+}
+
+// foreignSelector returns chromosomes that are not population members.
+type foreignSelector struct{}
+
+func (foreignSelector) Select(population []Chromosome, rng *rand.Rand) []Chromosome {
+	return []Chromosome{&MockChromosome{}, &MockChromosome{}}
+}
+
+// TestEngineRejectsForeignParents verifies a selector returning chromosomes
+// from outside the population surfaces an error instead of panicking
+func TestEngineRejectsForeignParents(t *testing.T) {
+	engine := NewEngine(newBitStrings(4, 4, 1),
+		WithGenerations(2),
+		WithSelector(foreignSelector{}),
+	)
+	err := engine.Run()
+	if err == nil || !strings.Contains(err.Error(), "not a member of the population") {
+		t.Errorf("Expected foreign parent error, got %v", err)
+	}
+}
+
+// TestEngineRejectsCheckpointer verifies checkpointing a typed engine fails
+// validation instead of aborting at the first save
+func TestEngineRejectsCheckpointer(t *testing.T) {
+	store := &memoryCheckpointer{}
+	engine := NewEngine(newBitStrings(4, 4, 1),
+		WithGenerations(5),
+		WithCheckpointer(store, 1),
+	)
+	err := engine.Run()
+	if err == nil || !strings.Contains(err.Error(), "WithCheckpointer is not supported by a standalone Engine") {
+		t.Errorf("Expected checkpointing to be rejected, got %v", err)
+	}
+	if store.saves != 0 {
+		t.Errorf("Expected no checkpoint to be saved, got %d", store.saves)
+	}
+}
+
+// TestEngineRejectsInitializers verifies initializers, which create
+// Chromosomes rather than T, are reported by Validate instead of failing
+// mid-run
+func TestEngineRejectsInitializers(t *testing.T) {
+	tests := []struct {
+		name       string
+		population []*bitString
+		opt        func(*GA)
+		want       string
+	}{
+		{"initializer", nil, WithInitializer(&mockInitializer{}, 4), "WithInitializer is not supported by Engine"},
+		{"restart", newBitStrings(4, 4, 1), WithRestart(Restart{Initializer: &mockInitializer{}}), "Restart.Initializer is not supported by Engine"},
+	}
+	for _, tt := range tests {
+		engine := NewEngine(tt.population, WithGenerations(5), WithConvergence(1, 0), tt.opt)
+		if err := engine.Run(); err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("%s: expected %q, got %v", tt.name, tt.want, err)
+		}
+	}
+
+	engine := NewEngine(newBitStrings(4, 4, 1), WithGenerations(5), WithConvergence(1, 0), WithRestart(Restart{Keep: 1}))
+	if err := engine.Validate(); err != nil {
+		t.Errorf("Expected restarts from mutated clones to be supported, got %v", err)
+	}
+}
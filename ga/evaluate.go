@@ -0,0 +1,180 @@
+package ga
+
+import (
+	"math/rand"
+	"sync"
+)
+
+// member is the engine's evaluated-individual wrapper. It caches the fitness
+// of the individual it holds so that sorting, selection, elitism and
+// convergence checks never score the same individual twice. The cache
+// survives across generations (elites and unmutated clones keep their score)
+// and is invalidated whenever the individual is mutated or replaced by a
+// crossover.
+//
+// Selectors receive members in place of the user's individuals while the
+// engine is running; Fitness on them returns the cached score.
+//
+// For constrained problems the member also caches the constraint violation
+// and, with WithAdaptivePenalty, the penalty subtracted from the fitness
+// that selectors see.
+//
+// Newly bred offspring are marked as bred, with the fitness of their fitter
+// parent, so the engine can measure how often breeding improves on the
+// parents.
+type member[T Individual[T]] struct {
+	value     T
+	fitness   float64
+	violation float64
+	penalty   float64
+	evaluated bool
+
+	bred          bool
+	parentFitness float64
+}
+
+// crossover combines the wrapped individuals, handing rng to individuals that
+// implement RandomIndividual. The offspring is a new genome and therefore
+// starts unevaluated.
+func (m *member[T]) crossover(other *member[T], rng *rand.Rand) *member[T] {
+	if ri, ok := any(m.value).(RandomIndividual[T]); ok {
+		return &member[T]{value: ri.CrossoverRand(other.value, rng)}
+	}
+	return &member[T]{value: m.value.Crossover(other.value)}
+}
+
+// mutate mutates the wrapped individual, handing rng to individuals that
+// implement RandomIndividual, and invalidates the cached score.
+func (m *member[T]) mutate(rng *rand.Rand) {
+	if ri, ok := any(m.value).(RandomIndividual[T]); ok {
+		ri.MutateRand(rng)
+	} else {
+		m.value.Mutate()
+	}
+	m.evaluated = false
+	m.penalty = 0
+}
+
+// mutateGenes mutates each locus of the wrapped individual, which must
+// implement GeneMutator, with probability rate and invalidates the cached
+// score if any locus changed.
+func (m *member[T]) mutateGenes(rate float64, rng *rand.Rand) {
+	if mutateGenes(m.individual().(GeneMutator), rate, rng) {
+		m.evaluated = false
+		m.penalty = 0
+	}
+}
+
+// clone copies the wrapped individual. The genome is unchanged, so the copy
+// inherits the cached score.
+func (m *member[T]) clone() *member[T] {
+	return &member[T]{
+		value:     m.value.Clone(),
+		fitness:   m.fitness,
+		violation: m.violation,
+		penalty:   m.penalty,
+		evaluated: m.evaluated,
+	}
+}
+
+// individual returns the user's individual behind the member, unwrapping the
+// GA's Chromosome adapter, so selectors can look for optional interfaces such
+// as CaseFitness.
+func (m *member[T]) individual() any {
+	return unwrapIndividual(m.value)
+}
+
+// unwrapIndividual returns the user's individual behind value, unwrapping
+// the GA's Chromosome adapter.
+func unwrapIndividual[T Individual[T]](value T) any {
+	if c, ok := any(value).(chromosomeIndividual); ok {
+		return c.Chromosome
+	}
+	return value
+}
+
+// Fitness returns the cached fitness score, less the constraint penalty
+// when an adaptive penalty is in effect.
+func (m *member[T]) Fitness() float64 {
+	return m.fitness - m.penalty
+}
+
+// Violation implements Constrained with the cached violation. Once a
+// penalty has been folded into Fitness the member reports itself feasible,
+// so Deb's rules do not count the violation twice.
+func (m *member[T]) Violation() float64 {
+	if m.penalty > 0 {
+		return 0
+	}
+	return m.violation
+}
+
+// Crossover implements Chromosome. other must be a member of the same
+// population.
+func (m *member[T]) Crossover(other Chromosome) Chromosome {
+	return &member[T]{value: m.value.Crossover(other.(*member[T]).value)}
+}
+
+// Mutate implements Chromosome and invalidates the cached score.
+func (m *member[T]) Mutate() {
+	m.value.Mutate()
+	m.evaluated = false
+}
+
+// Clone implements Chromosome.
+func (m *member[T]) Clone() Chromosome {
+	return m.clone()
+}
+
+// evaluate scores every member whose fitness is not cached yet, together with
+// its constraint violation, and returns the number of Fitness calls made.
+// With parallel evaluation enabled the work is spread over a bounded pool of
+// goroutines; scores are stored on the members themselves, so the outcome
+// does not depend on scheduling.
+func (e *Engine[T]) evaluate(population []*member[T]) int {
+	pending := make([]*member[T], 0, len(population))
+	for _, m := range population {
+		if !m.evaluated {
+			pending = append(pending, m)
+		}
+	}
+
+	forEach(len(pending), e.config.evaluationWorkers, func(i int) {
+		pending[i].fitness = pending[i].value.Fitness()
+		pending[i].violation = violationOf(pending[i].individual())
+		pending[i].evaluated = true
+	})
+	return len(pending)
+}
+
+// forEach calls fn for every index in [0, n), spreading the calls over at
+// most workers goroutines. With workers <= 1 the calls run serially in
+// order. fn must only touch data belonging to its index.
+func forEach(n, workers int, fn func(i int)) {
+	if workers > n {
+		workers = n
+	}
+	if workers <= 1 {
+		for i := 0; i < n; i++ {
+			fn(i)
+		}
+		return
+	}
+
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	for w := 0; w < workers; w++ {
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				fn(i)
+			}
+		}()
+	}
+	for i := 0; i < n; i++ {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+}
@@ -0,0 +1,152 @@
+package ga
+
+import (
+	"context"
+	"math/rand"
+	"sync/atomic"
+	"testing"
+)
+
+// countingChromosome counts Fitness calls across all of its descendants.
+type countingChromosome struct {
+	value float64
+	calls *int64
+}
+
+func (c *countingChromosome) Fitness() float64 {
+	atomic.AddInt64(c.calls, 1)
+	return c.value
+}
+
+func (c *countingChromosome) Crossover(other Chromosome) Chromosome {
+	o := other.(*countingChromosome)
+	return &countingChromosome{value: (c.value + o.value) / 2, calls: c.calls}
+}
+
+func (c *countingChromosome) Mutate() {
+	c.value += 0.5
+}
+
+func (c *countingChromosome) Clone() Chromosome {
+	return &countingChromosome{value: c.value, calls: c.calls}
+}
+
+func newCountingPopulation(size int, calls *int64) []Chromosome {
+	population := make([]Chromosome, size)
+	for i := range population {
+		population[i] = &countingChromosome{value: float64(i % 7), calls: calls}
+	}
+	return population
+}
+
+// TestParallelEvaluationScoresOncePerGeneration verifies that sorting and
+// selection reuse cached scores instead of calling Fitness repeatedly
+func TestParallelEvaluationScoresOncePerGeneration(t *testing.T) {
+	var calls int64
+	const size, generations = 50, 10
+
+	ga := New(
+		WithPopulation(newCountingPopulation(size, &calls)),
+		WithGenerations(generations),
+		WithElitism(false),
+		WithCrossoverRate(1.0),
+		WithMutationRate(1.0),
+		WithSelector(&TournamentSelector{TournamentSize: 5}),
+		WithParallelEvaluation(4),
+		WithRandomSeed(42),
+	)
+
+	if err := ga.Run(); err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+
+	if calls != size*generations {
+		t.Errorf("Expected %d fitness evaluations, got %d", size*generations, calls)
+	}
+}
+
+// TestParallelEvaluationReproducible verifies parallel and serial runs with
+// the same seed produce identical populations
+func TestParallelEvaluationReproducible(t *testing.T) {
+	run := func(options ...func(*GA)) []float64 {
+		var calls int64
+		options = append(options,
+			WithPopulation(newCountingPopulation(40, &calls)),
+			WithGenerations(15),
+			WithMutationRate(0.2),
+			WithRandomSeed(7),
+		)
+		ga := New(options...)
+		if err := ga.Run(); err != nil {
+			t.Fatalf("Run failed: %v", err)
+		}
+		values := make([]float64, len(ga.Population))
+		for i, c := range ga.Population {
+			values[i] = c.(*countingChromosome).value
+		}
+		return values
+	}
+
+	serial := run()
+	parallel := run(WithParallelEvaluation(8))
+
+	for i := range serial {
+		if serial[i] != parallel[i] {
+			t.Fatalf("Population differs at index %d: serial %f, parallel %f", i, serial[i], parallel[i])
+		}
+	}
+}
+
+// TestFitnessMemoization verifies elites and unmutated clones are not
+// re-evaluated and that the evaluation count is reported
+func TestFitnessMemoization(t *testing.T) {
+	var calls int64
+	const size = 30
+
+	ga := New(
+		WithPopulation(newCountingPopulation(size, &calls)),
+		WithGenerations(20),
+		WithCrossoverRate(0.0), // Offspring are clones of their parents
+		WithMutationRate(0.0),  // ...and are never mutated
+		WithSelector(&TournamentSelector{TournamentSize: 3}),
+		WithConvergence(5, 0.0),
+		WithRandomSeed(42),
+	)
+
+	result, err := ga.RunContext(context.Background())
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+
+	if calls != size {
+		t.Errorf("Expected each of the %d initial chromosomes to be scored once, got %d calls", size, calls)
+	}
+	if result.Evaluations != size {
+		t.Errorf("Expected %d reported evaluations, got %d", size, result.Evaluations)
+	}
+}
+
+// TestMutationInvalidatesCachedFitness verifies a mutated individual is
+// re-evaluated
+func TestMutationInvalidatesCachedFitness(t *testing.T) {
+	var calls int64
+	ind := &member[chromosomeIndividual]{value: chromosomeIndividual{&countingChromosome{value: 1, calls: &calls}}}
+
+	engine := New().engine()
+	if n := engine.evaluate([]*member[chromosomeIndividual]{ind}); n != 1 {
+		t.Fatalf("Expected 1 evaluation, got %d", n)
+	}
+
+	clone := ind.clone()
+	if n := engine.evaluate([]*member[chromosomeIndividual]{ind, clone}); n != 0 {
+		t.Errorf("Expected cached scores to be reused, got %d evaluations", n)
+	}
+
+	clone.mutate(rand.New(rand.NewSource(1)))
+	if n := engine.evaluate([]*member[chromosomeIndividual]{ind, clone}); n != 1 {
+		t.Errorf("Expected only the mutated clone to be re-evaluated, got %d evaluations", n)
+	}
+	if clone.Fitness() != 1.5 {
+		t.Errorf("Expected refreshed fitness 1.5, got %f", clone.Fitness())
+	}
+}
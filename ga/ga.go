@@ -14,13 +14,16 @@
 //	)
 //	err := algorithm.Run()
 //	best := algorithm.Best()
+//
+// Long-running evolutions can be bounded with RunContext, which stops between
+// generations once the context is cancelled or its deadline expires.
 package ga
 
 import (
+	"context"
 	"fmt"
-	"math"
 	"math/rand"
-	"sort"
+	"runtime"
 	"time"
 )
 
@@ -31,7 +34,7 @@ import (
 // IMPORTANT: Chromosome implementations must NOT use the global math/rand
 // package for any random operations. The GA framework provides thread-safe
 // random number generation through its internal RNG. If your Chromosome
-// needs randomness, it should be deterministic or accept an RNG parameter.
+// needs randomness, implement RandomChromosome so the GA can hand you its RNG.
 type Chromosome interface {
 	// Fitness returns the quality of this solution. Higher values are better.
 	Fitness() float64
@@ -48,12 +51,46 @@ type Chromosome interface {
 	Clone() Chromosome
 }
 
+// RandomChromosome is implemented by chromosomes whose genetic operators need
+// randomness. When a chromosome implements it, Run calls CrossoverRand and
+// MutateRand with the GA's own RNG instead of Crossover and Mutate, so runs
+// configured with WithRandomSeed are fully reproducible and concurrent GA
+// instances never contend on the global math/rand source.
+//
+// Crossover and Mutate are still required by Chromosome and are used when the
+// chromosome is driven outside of the GA.
+type RandomChromosome interface {
+	Chromosome
+
+	// CrossoverRand combines this chromosome with another using rng for all
+	// random decisions.
+	CrossoverRand(other Chromosome, rng *rand.Rand) Chromosome
+
+	// MutateRand introduces a random change using rng for all random decisions.
+	MutateRand(rng *rand.Rand)
+}
+
+// Equaler is implemented by individuals that can tell whether two genomes are
+// identical. Chromosomes implement Equaler[Chromosome]; Engine individuals
+// implement Equaler[T]. Features that look for duplicates, such as
+// WithDistinctElites, fall back to Hasher or to comparing fitness when it is
+// missing.
+type Equaler[T any] interface {
+	// Equal reports whether other has the same genome as the receiver.
+	Equal(other T) bool
+}
+
 // Selector defines how parent chromosomes are chosen for reproduction.
 // Different selection strategies (tournament, roulette, rank-based) can be
 // implemented by satisfying this interface.
 //
 // THREAD SAFETY: The rng parameter MUST be used for all random operations
 // instead of the global math/rand to ensure thread-safe concurrent execution.
+//
+// During Run the population passed to Select holds engine-managed wrappers
+// around the user's chromosomes whose Fitness returns a cached score, so
+// selectors may call Fitness as often as they like. Selectors should return
+// elements of that slice rather than chromosomes of their own.
 type Selector interface {
 	// Select chooses parent chromosomes from the population for breeding.
 	// The rng parameter provides a thread-safe random number generator that
@@ -72,9 +109,34 @@ type GA struct {
 	selector               Selector
 	BestChromosome         Chromosome
 	progressCallback       func(generation int, best Chromosome)
+	statsCallback          func(stats GenerationStats)
 	rng                    *rand.Rand
+	source                 *countingSource
 	convergenceGenerations int
 	convergenceThreshold   float64
+	evaluationWorkers      int
+	targetFitness          float64
+	hasTargetFitness       bool
+	checkpointer           Checkpointer
+	checkpointInterval     int
+	replacement            ReplacementStrategy
+	offspringPerStep       int
+	eliteCount             int
+	eliteFraction          float64
+	distinctElites         bool
+	penalty                *AdaptivePenalty
+	repairer               Repairer
+	mutationSchedule       RateSchedule
+	crossoverSchedule      RateSchedule
+	mutationMode           MutationMode
+	terminator             Terminator
+	restart                *Restart
+	hallOfFameSize         int
+	hallOfFame             []Chromosome
+	initializer            Initializer
+	initialSize            int
+	observers              []Observer
+	diversityMetrics       bool
 }
 
 // New creates a new genetic algorithm with default settings.
@@ -101,8 +163,8 @@ func New(options ...func(*GA)) *GA {
 		MutationRate:  0.01,
 		CrossoverRate: 0.8,
 		Elitism:       true,
-		rng:           rand.New(rand.NewSource(time.Now().UnixNano())),
 	}
+	ga.setRandomState(time.Now().UnixNano(), 0)
 	for _, option := range options {
 		option(ga)
 	}
@@ -118,24 +180,59 @@ func New(options ...func(*GA)) *GA {
 // if any issues are found.
 //
 // Checks include:
-//   - Population is not nil or empty
+//   - Population is not nil or empty, unless WithInitializer will create it
 //   - Generations is at least 1
 //   - MutationRate is between 0 and 1
 //   - CrossoverRate is between 0 and 1
 //   - Selector is not nil
 //   - No nil chromosomes in population
 func (ga *GA) Validate() error {
-	if len(ga.Population) == 0 {
+	if err := validatePopulationSize(ga.populationSize(len(ga.Population))); err != nil {
+		return err
+	}
+
+	if err := ga.validateSettings(); err != nil {
+		return err
+	}
+
+	// Validate population contains no nil chromosomes
+	for i, chromosome := range ga.Population {
+		if chromosome == nil {
+			return fmt.Errorf("population contains nil chromosome at index %d", i)
+		}
+		if err := ga.validateMutationMode(i, chromosome); err != nil {
+			return err
+		}
+		if err := ga.validateDistancer(i, chromosome, false); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// maxRecommendedPopulation is the largest population size that passes
+// validation; restarts never grow the population beyond it.
+const maxRecommendedPopulation = 100000
+
+// validatePopulationSize checks the population size shared by GA and Engine.
+func validatePopulationSize(size int) error {
+	if size == 0 {
 		return fmt.Errorf("population cannot be nil or empty")
 	}
 
 	// Warn about large populations
-	const maxRecommendedPopulation = 100000
-	if len(ga.Population) > maxRecommendedPopulation {
+	if size > maxRecommendedPopulation {
 		return fmt.Errorf("population size %d exceeds recommended maximum of %d (risk of out-of-memory errors)",
-			len(ga.Population), maxRecommendedPopulation)
+			size, maxRecommendedPopulation)
 	}
 
+	return nil
+}
+
+// validateSettings checks the population-independent settings shared by GA
+// and Engine.
+func (ga *GA) validateSettings() error {
 	if ga.Generations < 1 {
 		return fmt.Errorf("generations must be at least 1, got %d", ga.Generations)
 	}
@@ -148,17 +245,62 @@ func (ga *GA) Validate() error {
 		return fmt.Errorf("crossover rate must be between 0 and 1, got %f", ga.CrossoverRate)
 	}
 
+	if ga.mutationMode != PerIndividual && ga.mutationMode != PerGene {
+		return fmt.Errorf("unknown mutation mode %v", ga.mutationMode)
+	}
+
 	if ga.selector == nil {
 		return fmt.Errorf("selector cannot be nil")
 	}
 
-	// Validate population contains no nil chromosomes
-	for i, chromosome := range ga.Population {
-		if chromosome == nil {
-			return fmt.Errorf("population contains nil chromosome at index %d", i)
+	if ga.checkpointer != nil && ga.checkpointInterval < 1 {
+		return fmt.Errorf("checkpoint interval must be at least 1, got %d", ga.checkpointInterval)
+	}
+
+	if ga.eliteCount < 0 {
+		return fmt.Errorf("elite count cannot be negative, got %d", ga.eliteCount)
+	}
+
+	if ga.eliteFraction < 0 || ga.eliteFraction > 1 {
+		return fmt.Errorf("elite fraction must be between 0 and 1, got %f", ga.eliteFraction)
+	}
+
+	if ga.replacement != nil && ga.offspringPerStep < 1 {
+		return fmt.Errorf("offspring per step must be at least 1, got %d", ga.offspringPerStep)
+	}
+
+	if ga.restart != nil && ga.convergenceGenerations < 1 {
+		return fmt.Errorf("restart requires WithConvergence to detect stagnation")
+	}
+
+	if ga.hallOfFameSize < 0 {
+		return fmt.Errorf("hall of fame size must be non-negative, got %d", ga.hallOfFameSize)
+	}
+
+	if ga.initializer != nil && ga.initialSize < 1 {
+		return fmt.Errorf("initializer population size must be at least 1, got %d", ga.initialSize)
+	}
+
+	if err := validateInitializer(ga.initializer); err != nil {
+		return fmt.Errorf("invalid initializer: %w", err)
+	}
+
+	if ga.restart != nil {
+		if err := validateInitializer(ga.restart.Initializer); err != nil {
+			return fmt.Errorf("invalid restart initializer: %w", err)
+		}
+	}
+
+	for i, observer := range ga.observers {
+		if observer == nil {
+			return fmt.Errorf("observer %d is nil", i)
 		}
 	}
 
+	if !ga.diversityMetrics && readsUniqueRatio(ga.terminator) {
+		return fmt.Errorf("DiversityFloor without a Measure requires WithDiversityMetrics(true)")
+	}
+
 	return nil
 }
 
@@ -181,6 +323,8 @@ func WithGenerations(generations int) func(*GA) {
 // WithMutationRate sets the probability of mutation occurring (0.0 to 1.0).
 // Higher values introduce more randomness and exploration.
 // Typical values are between 0.01 and 0.1.
+// By default the rate applies once per offspring; see WithMutationMode for
+// per-gene mutation.
 func WithMutationRate(mutationRate float64) func(*GA) {
 	return func(ga *GA) {
 		ga.MutationRate = mutationRate
@@ -199,9 +343,54 @@ func WithCrossoverRate(crossoverRate float64) func(*GA) {
 // WithElitism determines whether the best chromosome is always preserved.
 // When enabled, the best solution is guaranteed to survive to the next generation.
 // This prevents losing good solutions but may slow convergence.
+// It replaces an earlier WithEliteCount or WithEliteFraction, so
+// WithElitism(true) always keeps exactly one elite.
 func WithElitism(elitism bool) func(*GA) {
 	return func(ga *GA) {
 		ga.Elitism = elitism
+		ga.eliteCount = 0
+		ga.eliteFraction = 0
+	}
+}
+
+// WithEliteCount preserves the k best chromosomes from one generation to the
+// next. Elites are cloned, so breeding never modifies them. WithEliteCount(0)
+// disables elitism; WithElitism(true) is equivalent to WithEliteCount(1).
+//
+// Example:
+//
+//	ga.WithEliteCount(5)
+func WithEliteCount(k int) func(*GA) {
+	return func(ga *GA) {
+		ga.Elitism = k > 0
+		ga.eliteCount = k
+		ga.eliteFraction = 0
+	}
+}
+
+// WithEliteFraction preserves the best fraction (0.0 to 1.0) of the
+// population, rounded to the nearest individual but at least one when
+// fraction is positive. WithEliteFraction(0) disables elitism.
+//
+// Example:
+//
+//	ga.WithEliteFraction(0.05) // top 5%
+func WithEliteFraction(fraction float64) func(*GA) {
+	return func(ga *GA) {
+		ga.Elitism = fraction > 0
+		ga.eliteFraction = fraction
+		ga.eliteCount = 0
+	}
+}
+
+// WithDistinctElites skips elites whose genome equals one already preserved,
+// so a converging population does not fill its elite slots with copies of
+// the same solution. Genomes are compared with Equal when the chromosome
+// implements Equaler and by fitness otherwise. If the population has fewer
+// distinct genomes than elite slots, fewer elites are kept.
+func WithDistinctElites(enabled bool) func(*GA) {
+	return func(ga *GA) {
+		ga.distinctElites = enabled
 	}
 }
 
@@ -231,6 +420,25 @@ func WithProgressCallback(callback func(generation int, best Chromosome)) func(*
 	}
 }
 
+// WithStatsCallback sets a callback that receives the statistics of each
+// generation, including the diversity measures (enable the genotypic ones
+// with WithDiversityMetrics), right after the progress callback. The same values are collected in Result.History; the callback
+// lets long runs watch for premature convergence as it happens.
+//
+// Example:
+//
+//	ga.WithDiversityMetrics(true),
+//	ga.WithStatsCallback(func(stats ga.GenerationStats) {
+//	    if stats.UniqueRatio < 0.2 {
+//	        log.Printf("generation %d: diversity collapsed", stats.Generation)
+//	    }
+//	})
+func WithStatsCallback(callback func(stats GenerationStats)) func(*GA) {
+	return func(ga *GA) {
+		ga.statsCallback = callback
+	}
+}
+
 // WithRandomSeed sets a specific seed for the random number generator.
 // This is useful for reproducible results in testing and debugging.
 // If not specified, a time-based seed is used automatically.
@@ -240,7 +448,7 @@ func WithProgressCallback(callback func(generation int, best Chromosome)) func(*
 //	ga.WithRandomSeed(12345)  // Same seed produces same results
 func WithRandomSeed(seed int64) func(*GA) {
 	return func(ga *GA) {
-		ga.rng = rand.New(rand.NewSource(seed))
+		ga.setRandomState(seed, 0)
 	}
 }
 
@@ -262,6 +470,51 @@ func WithConvergence(generations int, threshold float64) func(*GA) {
 	}
 }
 
+// Result summarizes a completed (or interrupted) run: the best chromosome,
+// why and when the run stopped, how many evaluations it took, and a
+// per-generation history of fitness statistics.
+type Result struct {
+	// Best is the best chromosome found so far. It is nil only if the run
+	// stopped before the first generation was evaluated.
+	Best Chromosome
+
+	Stats
+}
+
+// WithTargetFitness stops the algorithm as soon as the best fitness reaches
+// target. The run then reports StopTargetReached.
+//
+// Example:
+//
+//	ga.WithTargetFitness(20)  // One-Max with 20 genes: stop at the optimum
+func WithTargetFitness(target float64) func(*GA) {
+	return func(ga *GA) {
+		ga.targetFitness = target
+		ga.hasTargetFitness = true
+	}
+}
+
+// WithParallelEvaluation evaluates the population across a pool of worker
+// goroutines. Every new chromosome is scored exactly once and the cached
+// scores are used for sorting and selection, which matters when Fitness is
+// expensive. A workers value <= 0 uses runtime.GOMAXPROCS(0).
+//
+// Fitness must be safe to call concurrently on distinct chromosomes. Results
+// stay reproducible under WithRandomSeed because scores are stored on each
+// individual and the RNG is never touched by the workers.
+//
+// Example:
+//
+//	ga.WithParallelEvaluation(8)  // Score up to 8 chromosomes at a time
+func WithParallelEvaluation(workers int) func(*GA) {
+	return func(ga *GA) {
+		if workers <= 0 {
+			workers = runtime.GOMAXPROCS(0)
+		}
+		ga.evaluationWorkers = workers
+	}
+}
+
 // Run executes the genetic algorithm for the configured number of generations
 // or until convergence is detected.
 //
@@ -275,6 +528,8 @@ func WithConvergence(generations int, threshold float64) func(*GA) {
 //     - Creates next generation via selection, crossover, and mutation
 //  3. Returns nil on success, or an error if configuration is invalid
 //
+// Run is equivalent to RunContext with context.Background().
+//
 // THREAD SAFETY: Each GA instance has its own RNG and can run concurrently
 // with other GA instances. However, DO NOT:
 //   - Call Run() on the same GA instance from multiple goroutines simultaneously
@@ -282,94 +537,121 @@ func WithConvergence(generations int, threshold float64) func(*GA) {
 //   - Use global math/rand in Chromosome or Selector implementations
 //
 // All Chromosome methods (Crossover, Mutate) and Selector methods must be
-// thread-safe and must NOT use global math/rand.
+// thread-safe and must NOT use global math/rand. Chromosomes that need
+// randomness should implement RandomChromosome.
 func (ga *GA) Run() error {
+	_, err := ga.RunContext(context.Background())
+	return err
+}
+
+// RunContext executes the genetic algorithm like Run, but stops early when ctx
+// is cancelled or its deadline expires. The context is checked before every
+// generation and periodically while breeding the next generation.
+//
+// On cancellation the returned Result still carries the best chromosome found
+// so far, and the error wraps ctx.Err(), so callers can test it with
+// errors.Is(err, context.Canceled) or errors.Is(err, context.DeadlineExceeded).
+//
+// Example:
+//
+//	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+//	defer cancel()
+//	result, err := algorithm.RunContext(ctx)
+//	if errors.Is(err, context.DeadlineExceeded) {
+//	    fmt.Println("time is up, best so far:", result.Best.Fitness())
+//	}
+func (ga *GA) RunContext(ctx context.Context) (Result, error) {
+	if err := ga.initialize(); err != nil {
+		return Result{}, err
+	}
+
 	// Validate configuration before running
 	if err := ga.Validate(); err != nil {
-		return fmt.Errorf("invalid GA configuration: %w", err)
+		return Result{}, fmt.Errorf("invalid GA configuration: %w", err)
 	}
 
-	var lastBestFitness float64 = math.Inf(-1)
-	var generationsWithoutImprovement int
-
-	for i := 0; i < ga.Generations; i++ {
-		// Sort the population by fitness.
-		sort.Slice(ga.Population, func(i, j int) bool {
-			return ga.Population[i].Fitness() > ga.Population[j].Fitness()
-		})
-
-		// Update the best chromosome.
-		currentBestFitness := ga.Population[0].Fitness()
-		if ga.BestChromosome == nil || currentBestFitness > ga.BestChromosome.Fitness() {
-			ga.BestChromosome = ga.Population[0]
-		}
+	return ga.runEngine(ctx, ga.engine())
+}
 
-		// Check for convergence
-		if ga.convergenceGenerations > 0 {
-			improvement := currentBestFitness - lastBestFitness
-			if improvement > ga.convergenceThreshold {
-				// Significant improvement, reset counter
-				generationsWithoutImprovement = 0
-			} else {
-				// No improvement, increment counter
-				generationsWithoutImprovement++
-				if generationsWithoutImprovement >= ga.convergenceGenerations {
-					// Converged - call callback one last time and exit
-					if ga.progressCallback != nil {
-						ga.progressCallback(i, ga.BestChromosome)
-					}
-					return nil
-				}
-			}
-			lastBestFitness = currentBestFitness
-		}
+// runEngine runs engine and copies its final state back into the GA.
+func (ga *GA) runEngine(ctx context.Context, engine *Engine[chromosomeIndividual]) (Result, error) {
+	stats, err := engine.RunContext(ctx)
+	ga.collect(engine)
+	return Result{Best: ga.BestChromosome, Stats: stats}, err
+}
 
-		// Call progress callback if provided
-		if ga.progressCallback != nil {
-			ga.progressCallback(i, ga.BestChromosome)
-		}
+// collect copies the population, best chromosome and hall of fame of engine
+// back into the GA.
+func (ga *GA) collect(engine *Engine[chromosomeIndividual]) {
+	ga.Population = make([]Chromosome, len(engine.Population))
+	for i, c := range engine.Population {
+		ga.Population[i] = c.Chromosome
+	}
+	if engine.hasBest {
+		ga.BestChromosome = engine.best.Chromosome
+	}
+	ga.hallOfFame = nil
+	for _, c := range engine.HallOfFame() {
+		ga.hallOfFame = append(ga.hallOfFame, c.Chromosome)
+	}
+}
 
-		// Create the next generation.
-		nextGeneration := make([]Chromosome, len(ga.Population))
-		nextIndex := 0
+// engine builds the Engine that runs this GA. The GA itself serves as the
+// engine's configuration.
+func (ga *GA) engine() *Engine[chromosomeIndividual] {
+	population := make([]chromosomeIndividual, len(ga.Population))
+	for i, c := range ga.Population {
+		population[i] = chromosomeIndividual{c}
+	}
 
-		// Apply elitism if enabled
-		if ga.Elitism {
-			nextGeneration[0] = ga.Population[0] // Current generation's best
-			nextIndex = 1
-		}
+	engine := &Engine[chromosomeIndividual]{
+		Population: population,
+		config:     ga,
+		toChromosome: func(c chromosomeIndividual) Chromosome {
+			return c.Chromosome
+		},
+		fromChromosome: func(c Chromosome) (chromosomeIndividual, bool) {
+			return chromosomeIndividual{c}, c != nil
+		},
+	}
+	if ga.BestChromosome != nil {
+		engine.best = chromosomeIndividual{ga.BestChromosome}
+		engine.hasBest = true
+	}
+	return engine
+}
 
-		// Fill the rest of the population
-		// NOTE: No mutex needed here because each GA instance has its own RNG.
-		// The ga.rng is not shared across goroutines, making this safe for
-		// concurrent execution of multiple GA instances.
-		for nextIndex < len(ga.Population) {
-			// Select parents using the GA's thread-safe RNG
-			parents := ga.selector.Select(ga.Population, ga.rng)
-
-			var offspring Chromosome
-
-			// Crossover
-			if ga.rng.Float64() < ga.CrossoverRate {
-				offspring = parents[0].Crossover(parents[1])
-			} else {
-				// If no crossover, clone the first parent
-				offspring = parents[0].Clone()
-			}
+// chromosomeIndividual adapts a Chromosome to the Individual interface so
+// that GA can run on Engine.
+type chromosomeIndividual struct {
+	Chromosome
+}
 
-			// Mutation
-			if ga.rng.Float64() < ga.MutationRate {
-				offspring.Mutate()
-			}
+func (c chromosomeIndividual) Crossover(other chromosomeIndividual) chromosomeIndividual {
+	return chromosomeIndividual{c.Chromosome.Crossover(other.Chromosome)}
+}
 
-			nextGeneration[nextIndex] = offspring
-			nextIndex++
-		}
+func (c chromosomeIndividual) Clone() chromosomeIndividual {
+	return chromosomeIndividual{c.Chromosome.Clone()}
+}
 
-		ga.Population = nextGeneration
+// CrossoverRand forwards to RandomChromosome when the chromosome implements
+// it and falls back to Crossover otherwise.
+func (c chromosomeIndividual) CrossoverRand(other chromosomeIndividual, rng *rand.Rand) chromosomeIndividual {
+	if rc, ok := c.Chromosome.(RandomChromosome); ok {
+		return chromosomeIndividual{rc.CrossoverRand(other.Chromosome, rng)}
 	}
+	return c.Crossover(other)
+}
 
-	return nil
+// MutateRand forwards to RandomChromosome when the chromosome implements it
+// and falls back to Mutate otherwise.
+func (c chromosomeIndividual) MutateRand(rng *rand.Rand) {
+	if rc, ok := c.Chromosome.(RandomChromosome); ok {
+		rc.MutateRand(rng)
+		return
+	}
+	c.Chromosome.Mutate()
 }
 
 // Best returns the best chromosome found during the algorithm's execution.
@@ -385,6 +667,9 @@ func (ga *GA) Best() Chromosome {
 // Tournament selection balances selection pressure with diversity:
 //   - Larger tournament sizes increase selection pressure (favor fit individuals)
 //   - Smaller tournament sizes maintain more diversity
+//
+// Competitors are compared with Fitter, so constrained chromosomes follow
+// Deb's feasibility rules.
 type TournamentSelector struct {
 	// TournamentSize is the number of individuals competing in each tournament.
 	// Typical values are 2-5. Default is 2 if not specified or if <= 0.
@@ -396,7 +681,7 @@ type TournamentSelector struct {
 //
 // The selection process:
 //  1. Randomly select TournamentSize individuals
-//  2. Choose the one with highest fitness
+//  2. Choose the fittest one (see Fitter)
 //  3. Repeat to select the second parent
 //
 // THREAD SAFETY: Uses the provided rng parameter instead of global math/rand,
@@ -421,7 +706,7 @@ func (s *TournamentSelector) Select(population []Chromosome, rng *rand.Rand) []C
 		best := population[rng.Intn(len(population))]
 		for j := 1; j < tournamentSize; j++ {
 			competitor := population[rng.Intn(len(population))]
-			if competitor.Fitness() > best.Fitness() {
+			if Fitter(competitor, best) {
 				best = competitor
 			}
 		}
@@ -1,9 +1,13 @@
 package ga
 
 import (
+	"context"
+	"errors"
 	"math/rand"
+	"reflect"
 	"sync"
 	"testing"
+	"time"
 )
 
 type MockChromosome struct {
@@ -651,6 +655,210 @@ func TestZeroCrossoverRate(t *testing.T) {
 	}
 }
 
+// TestRunContextCancellation verifies a cancelled run returns the best so far
+func TestRunContextCancellation(t *testing.T) {
+	population := []Chromosome{
+		&MockChromosome{fitness: 1.0},
+		&MockChromosome{fitness: 2.0},
+		&MockChromosome{fitness: 3.0},
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	ga := New(
+		WithPopulation(population),
+		WithGenerations(100),
+		WithRandomSeed(42),
+		WithProgressCallback(func(generation int, best Chromosome) {
+			if generation == 3 {
+				cancel()
+			}
+		}),
+	)
+
+	result, err := ga.RunContext(ctx)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("Expected context.Canceled, got %v", err)
+	}
+	if result.Best == nil {
+		t.Fatal("Expected best-so-far chromosome on cancellation")
+	}
+	if result.Best != ga.Best() {
+		t.Error("Expected result best to match Best()")
+	}
+	if result.Generations != 4 {
+		t.Errorf("Expected 4 evaluated generations, got %d", result.Generations)
+	}
+}
+
+// TestRunContextDeadline verifies an expired deadline stops the run immediately
+func TestRunContextDeadline(t *testing.T) {
+	population := []Chromosome{
+		&MockChromosome{fitness: 1.0},
+		&MockChromosome{fitness: 2.0},
+	}
+
+	ctx, cancel := context.WithDeadline(context.Background(), time.Now().Add(-time.Second))
+	defer cancel()
+
+	ga := New(
+		WithPopulation(population),
+		WithGenerations(10),
+	)
+
+	result, err := ga.RunContext(ctx)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Expected context.DeadlineExceeded, got %v", err)
+	}
+	if result.Generations != 0 {
+		t.Errorf("Expected no generations to run, got %d", result.Generations)
+	}
+}
+
+// TestRunContextCompletes verifies an uncancelled run reports its generations
+func TestRunContextCompletes(t *testing.T) {
+	population := []Chromosome{
+		&MockChromosome{fitness: 1.0},
+		&MockChromosome{fitness: 2.0},
+	}
+
+	ga := New(
+		WithPopulation(population),
+		WithGenerations(7),
+		WithRandomSeed(42),
+	)
+
+	result, err := ga.RunContext(context.Background())
+	if err != nil {
+		t.Fatalf("RunContext failed: %v", err)
+	}
+	if result.Generations != 7 {
+		t.Errorf("Expected 7 generations, got %d", result.Generations)
+	}
+	if result.Best == nil {
+		t.Error("Expected best chromosome to be set")
+	}
+}
+
+// sortedMembers wraps, scores and sorts a GA's population the way the engine
+// does at the start of a generation.
+func sortedMembers(ga *GA) (*Engine[chromosomeIndividual], []*member[chromosomeIndividual]) {
+	engine := ga.engine()
+	population := engine.newRunState().population
+	engine.evaluate(population)
+	sortByFitness(population)
+	return engine, population
+}
+
+func memberFitness(population []*member[chromosomeIndividual]) []float64 {
+	fitness := make([]float64, len(population))
+	for i, m := range population {
+		fitness[i] = m.fitness
+	}
+	return fitness
+}
+
+// TestEliteCountAndFraction verifies how many elites are carried over
+func TestEliteCountAndFraction(t *testing.T) {
+	population := mockPopulation(1, 2, 3, 4, 5, 6, 7, 8)
+	tests := []struct {
+		name   string
+		option func(*GA)
+		want   []float64
+	}{
+		{"default elitism", WithElitism(true), []float64{8}},
+		{"no elitism", WithElitism(false), []float64{}},
+		{"count", WithEliteCount(3), []float64{8, 7, 6}},
+		{"count above population size", WithEliteCount(20), []float64{8, 7, 6, 5, 4, 3, 2, 1}},
+		{"fraction", WithEliteFraction(0.25), []float64{8, 7}},
+		{"small fraction keeps one", WithEliteFraction(0.01), []float64{8}},
+		{"elitism after count", func(ga *GA) { WithEliteCount(5)(ga); WithElitism(true)(ga) }, []float64{8}},
+		{"elitism after fraction", func(ga *GA) { WithEliteFraction(0.5)(ga); WithElitism(true)(ga) }, []float64{8}},
+	}
+	for _, tt := range tests {
+		engine, members := sortedMembers(New(WithPopulation(population), tt.option))
+		if got := memberFitness(engine.elites(members)); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: expected elites %v, got %v", tt.name, tt.want, got)
+		}
+	}
+}
+
+// TestDistinctElites verifies duplicate genomes are skipped, comparing with
+// Equal when available and by fitness otherwise
+func TestDistinctElites(t *testing.T) {
+	routes := newTSPPopulation(4, 6, 1)
+	routes[1] = routes[0].Clone()
+	engine, members := sortedMembers(New(
+		WithPopulation(routes),
+		WithEliteCount(3),
+		WithDistinctElites(true),
+	))
+	elites := engine.elites(members)
+	if len(elites) != 3 {
+		t.Fatalf("Expected 3 elites, got %d", len(elites))
+	}
+	for i := range elites {
+		for j := i + 1; j < len(elites); j++ {
+			if routeNames(elites[i].value.Chromosome) == routeNames(elites[j].value.Chromosome) {
+				t.Errorf("Elites %d and %d are the same route", i, j)
+			}
+		}
+	}
+
+	engine, members = sortedMembers(New(
+		WithPopulation(mockPopulation(5, 5, 5, 4)),
+		WithEliteCount(3),
+		WithDistinctElites(true),
+	))
+	if got := memberFitness(engine.elites(members)); !reflect.DeepEqual(got, []float64{5, 4}) {
+		t.Errorf("Expected elites deduplicated by fitness [5 4], got %v", got)
+	}
+}
+
+// TestElitesAreCloned verifies elites survive unchanged as copies, so
+// breeding cannot modify the chromosomes they came from
+func TestElitesAreCloned(t *testing.T) {
+	population := mockPopulation(1, 2, 3, 4, 5, 6)
+	ga := New(
+		WithPopulation(population),
+		WithGenerations(1),
+		WithMutationRate(1),
+		WithEliteCount(2),
+		WithRandomSeed(3),
+	)
+	if err := ga.Run(); err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+
+	for _, want := range []float64{6, 5} {
+		found := false
+		for _, c := range ga.Population {
+			if c.Fitness() == want {
+				found = true
+				for _, original := range population {
+					if c == original {
+						t.Errorf("Elite with fitness %v is the original chromosome, not a clone", want)
+					}
+				}
+			}
+		}
+		if !found {
+			t.Errorf("Expected elite with fitness %v in the next generation", want)
+		}
+	}
+}
+
+// TestValidateEliteSettings verifies invalid elite settings are rejected
+func TestValidateEliteSettings(t *testing.T) {
+	for _, option := range []func(*GA){WithEliteCount(-1), WithEliteFraction(1.5)} {
+		ga := New(WithPopulation(mockPopulation(1, 2)), option)
+		if err := ga.Validate(); err == nil {
+			t.Error("Expected validation error for invalid elite settings")
+		}
+	}
+}
+
 // Helper function to check if string contains substring
 func contains(s, substr string) bool {
 	return len(s) >= len(substr) && (s == substr || len(s) > len(substr) && containsHelper(s, substr))
@@ -0,0 +1,114 @@
+package ga
+
+import (
+	"fmt"
+	"sort"
+)
+
+// Hasher is implemented by individuals that can summarize their genome in a
+// hash. Equal genomes must have equal hashes. Features that look for
+// duplicates, such as WithHallOfFame and WithDistinctElites, use it to rule
+// out most comparisons cheaply; without Equaler, equal hashes count as
+// duplicates.
+type Hasher interface {
+	// Hash returns a hash of the genome.
+	Hash() uint64
+}
+
+// WithHallOfFame keeps the size best distinct individuals seen at any point
+// of the run, ranked by Deb's rules on their unpenalized fitness. Entries
+// are deep clones, so later generations never change them, and duplicates
+// are detected with Equaler and Hasher when the individuals implement them,
+// or by equal fitness otherwise. Read the entries with GA.HallOfFame or
+// Engine.HallOfFame; they are included in checkpoints.
+//
+// Example:
+//
+//	ga.WithHallOfFame(10)
+func WithHallOfFame(size int) func(*GA) {
+	return func(ga *GA) {
+		ga.hallOfFameSize = size
+	}
+}
+
+// HallOfFame returns the best distinct chromosomes found during the last
+// run, best first, or nil without WithHallOfFame.
+func (ga *GA) HallOfFame() []Chromosome {
+	return append([]Chromosome(nil), ga.hallOfFame...)
+}
+
+// HallOfFame returns the best distinct individuals found during the last
+// run, best first, or nil without WithHallOfFame.
+func (e *Engine[T]) HallOfFame() []T {
+	if e.hallOfFame == nil {
+		return nil
+	}
+	fame := make([]T, len(e.hallOfFame))
+	for i, m := range e.hallOfFame {
+		fame[i] = m.value
+	}
+	return fame
+}
+
+// updateHallOfFame enters clones of the members of an evaluated population
+// that rank above the current entries and are not already present.
+func (e *Engine[T]) updateHallOfFame(population []*member[T]) {
+	size := e.config.hallOfFameSize
+	if size == 0 {
+		return
+	}
+	for _, m := range population {
+		if n := len(e.hallOfFame); n == size {
+			worst := e.hallOfFame[n-1]
+			if !ranksAbove(m.fitness, m.violation, worst.fitness, worst.violation) {
+				continue
+			}
+		}
+		if e.inHallOfFame(m) {
+			continue
+		}
+
+		entry := m.clone()
+		entry.penalty = 0
+		i := sort.Search(len(e.hallOfFame), func(i int) bool {
+			return ranksAbove(m.fitness, m.violation, e.hallOfFame[i].fitness, e.hallOfFame[i].violation)
+		})
+		e.hallOfFame = append(e.hallOfFame, nil)
+		copy(e.hallOfFame[i+1:], e.hallOfFame[i:])
+		e.hallOfFame[i] = entry
+		if len(e.hallOfFame) > size {
+			e.hallOfFame = e.hallOfFame[:size]
+		}
+	}
+}
+
+// inHallOfFame reports whether the hall of fame holds m's genome.
+func (e *Engine[T]) inHallOfFame(m *member[T]) bool {
+	for _, entry := range e.hallOfFame {
+		if e.sameGenome(m, entry) {
+			return true
+		}
+	}
+	return false
+}
+
+// hallOfFameOf restores hall of fame entries from their Chromosome views and
+// scores, as saved in a checkpoint.
+func (e *Engine[T]) hallOfFameOf(chromosomes []Chromosome, fitness, violation []float64) ([]*member[T], error) {
+	if len(fitness) != len(chromosomes) || len(violation) != len(chromosomes) {
+		return nil, fmt.Errorf("corrupt checkpoint: %d hall of fame chromosomes but %d fitness values and %d violations",
+			len(chromosomes), len(fitness), len(violation))
+	}
+	if len(chromosomes) == 0 {
+		return nil, nil
+	}
+	fame := make([]*member[T], len(chromosomes))
+	for i, c := range chromosomes {
+		value, ok := e.individualFrom(c)
+		if !ok {
+			return nil, fmt.Errorf("checkpoint chromosome %T is not a %T", c, value)
+		}
+		fame[i] = &member[T]{value: value, fitness: fitness[i], violation: violation[i], evaluated: true}
+	}
+	return fame, nil
+}
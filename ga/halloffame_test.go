@@ -0,0 +1,168 @@
+package ga
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+)
+
+// hashedChromosome is a MockChromosome that identifies its genome by key
+type hashedChromosome struct {
+	MockChromosome
+	key uint64
+}
+
+func (c *hashedChromosome) Hash() uint64 {
+	return c.key
+}
+
+func (c *hashedChromosome) Clone() Chromosome {
+	return &hashedChromosome{MockChromosome: c.MockChromosome, key: c.key}
+}
+
+// TestHallOfFameKeepsBestDistinct verifies the hall of fame holds distinct
+// clones of the best routes found, best first
+func TestHallOfFameKeepsBestDistinct(t *testing.T) {
+	algorithm := New(
+		WithPopulation(newTSPPopulation(20, 8, 1)),
+		WithGenerations(30),
+		WithHallOfFame(5),
+		WithRandomSeed(1),
+	)
+	result, err := algorithm.RunContext(context.Background())
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+
+	fame := algorithm.HallOfFame()
+	if len(fame) != 5 {
+		t.Fatalf("Expected 5 entries, got %d", len(fame))
+	}
+	if fame[0].Fitness() != result.Best.Fitness() {
+		t.Errorf("Expected the first entry to be the best found, got %f and %f", fame[0].Fitness(), result.Best.Fitness())
+	}
+	for i, c := range fame {
+		if i > 0 && c.Fitness() > fame[i-1].Fitness() {
+			t.Errorf("Entry %d ranks above entry %d", i, i-1)
+		}
+		for _, other := range fame[:i] {
+			if c.(*TSPChromosome).Equal(other) {
+				t.Errorf("Entry %d duplicates an earlier entry", i)
+			}
+		}
+		for _, member := range algorithm.Population {
+			if c == member {
+				t.Errorf("Entry %d is shared with the population", i)
+			}
+		}
+	}
+}
+
+// TestHallOfFameDeduplicates verifies duplicates are detected by hash, and by
+// fitness when individuals cannot be hashed
+func TestHallOfFameDeduplicates(t *testing.T) {
+	engine := New(WithHallOfFame(3)).engine()
+	members := func(population ...Chromosome) []*member[chromosomeIndividual] {
+		ms := make([]*member[chromosomeIndividual], len(population))
+		for i, c := range population {
+			ms[i] = &member[chromosomeIndividual]{value: chromosomeIndividual{c}, fitness: c.Fitness(), evaluated: true}
+		}
+		return ms
+	}
+	fitness := func() []float64 {
+		var f []float64
+		for _, m := range engine.hallOfFame {
+			f = append(f, m.fitness)
+		}
+		return f
+	}
+
+	engine.updateHallOfFame(members(mockPopulation(3, 1, 3, 2, 2)...))
+	if got := fitness(); len(got) != 3 || got[0] != 3 || got[1] != 2 || got[2] != 1 {
+		t.Errorf("Expected fitness 3, 2, 1 without hashes, got %v", got)
+	}
+
+	engine.hallOfFame = nil
+	engine.updateHallOfFame(members(
+		&hashedChromosome{MockChromosome{fitness: 3}, 1},
+		&hashedChromosome{MockChromosome{fitness: 3}, 2},
+		&hashedChromosome{MockChromosome{fitness: 3}, 1},
+		&hashedChromosome{MockChromosome{fitness: 1}, 3},
+		&hashedChromosome{MockChromosome{fitness: 2}, 4},
+	))
+	if got := fitness(); len(got) != 3 || got[0] != 3 || got[1] != 3 || got[2] != 2 {
+		t.Errorf("Expected fitness 3, 3, 2 with hashes, got %v", got)
+	}
+}
+
+// TestHallOfFameResumesFromCheckpoint verifies a resumed run ends with the
+// same hall of fame as an uninterrupted one
+func TestHallOfFameResumesFromCheckpoint(t *testing.T) {
+	options := func() []func(*GA) {
+		return []func(*GA){
+			WithGenerations(30),
+			WithHallOfFame(4),
+			WithRandomSeed(3),
+		}
+	}
+
+	reference := New(append(options(), WithPopulation(newTSPPopulation(10, 8, 1)))...)
+	if _, err := reference.RunContext(context.Background()); err != nil {
+		t.Fatalf("Reference run failed: %v", err)
+	}
+
+	path := filepath.Join(t.TempDir(), "run.ckpt")
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	interrupted := New(append(options(),
+		WithPopulation(newTSPPopulation(10, 8, 1)),
+		WithCheckpointer(&FileCheckpointer{Path: path}, 5),
+		WithProgressCallback(func(generation int, best Chromosome) {
+			if generation == 17 {
+				cancel()
+			}
+		}),
+	)...)
+	if _, err := interrupted.RunContext(ctx); !errors.Is(err, context.Canceled) {
+		t.Fatalf("Expected interrupted run to be cancelled, got %v", err)
+	}
+
+	cp, err := (&FileCheckpointer{Path: path}).Load()
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if len(cp.HallOfFame) != 4 || len(cp.HallOfFameFitness) != 4 || len(cp.HallOfFameViolation) != 4 {
+		t.Fatalf("Expected 4 hall of fame entries in the checkpoint, got %d", len(cp.HallOfFame))
+	}
+
+	resumed := New(options()...)
+	if _, err := resumed.Resume(path); err != nil {
+		t.Fatalf("Resume failed: %v", err)
+	}
+	want, got := reference.HallOfFame(), resumed.HallOfFame()
+	if len(got) != len(want) {
+		t.Fatalf("Expected %d entries, got %d", len(want), len(got))
+	}
+	for i := range want {
+		if routeNames(got[i]) != routeNames(want[i]) {
+			t.Errorf("Hall of fame differs at index %d", i)
+		}
+	}
+}
+
+// TestHallOfFameDisabled verifies there is no hall of fame by default and
+// that a negative size is rejected
+func TestHallOfFameDisabled(t *testing.T) {
+	algorithm := New(WithPopulation(mockPopulation(1, 2, 3)), WithGenerations(3))
+	if err := algorithm.Run(); err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	if fame := algorithm.HallOfFame(); fame != nil {
+		t.Errorf("Expected no hall of fame, got %d entries", len(fame))
+	}
+
+	if err := New(WithPopulation(mockPopulation(1, 2)), WithHallOfFame(-1)).Validate(); err == nil {
+		t.Error("Expected an error for a negative hall of fame size")
+	}
+}
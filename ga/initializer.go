@@ -0,0 +1,273 @@
+package ga
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+)
+
+// Initializer creates individuals for a population, using rng for all random
+// decisions so seeded runs stay reproducible. It builds the initial
+// population with WithInitializer and fresh individuals with WithRestart.
+//
+// Initializers create Chromosomes, so they are only supported by GA: an
+// Engine rejects WithInitializer and Restart.Initializer in Validate.
+//
+// THREAD SAFETY: The rng parameter MUST be used for all random operations
+// instead of the global math/rand.
+type Initializer interface {
+	// Initialize returns size new individuals.
+	Initialize(size int, rng *rand.Rand) []Chromosome
+}
+
+// InitializerFunc adapts an ordinary function to the Initializer interface.
+type InitializerFunc func(size int, rng *rand.Rand) []Chromosome
+
+// Initialize calls f(size, rng).
+func (f InitializerFunc) Initialize(size int, rng *rand.Rand) []Chromosome {
+	return f(size, rng)
+}
+
+// WithInitializer makes the GA create its initial population of size
+// individuals with initializer, drawing on the GA's own RNG, so a run with
+// WithRandomSeed is reproducible from the seed alone. It replaces
+// WithPopulation: the initializer is only used when the population is empty
+// when the run starts.
+//
+// Example:
+//
+//	routes := ga.TSPRoutes(cities)
+//	ga.WithInitializer(ga.ShuffleInitializer{Length: len(cities), Build: routes}, 100)
+func WithInitializer(initializer Initializer, size int) func(*GA) {
+	return func(ga *GA) {
+		ga.initializer = initializer
+		ga.initialSize = size
+	}
+}
+
+// populationSize returns the size the population will have when the run
+// starts: its current size, or the WithInitializer size if it is empty.
+func (ga *GA) populationSize(current int) int {
+	if current == 0 && ga.initializer != nil {
+		return ga.initialSize
+	}
+	return current
+}
+
+// initialize fills an empty population from the configured initializer.
+func (ga *GA) initialize() error {
+	if len(ga.Population) > 0 || ga.initializer == nil {
+		return nil
+	}
+	if err := validatePopulationSize(ga.initialSize); err != nil {
+		return fmt.Errorf("failed to initialize population: %w", err)
+	}
+	if err := validateInitializer(ga.initializer); err != nil {
+		return fmt.Errorf("failed to initialize population: %w", err)
+	}
+	population, err := initialChromosomes(ga.initializer, ga.initialSize, ga.rng)
+	if err != nil {
+		return fmt.Errorf("failed to initialize population: %w", err)
+	}
+	ga.Population = population
+	return nil
+}
+
+// validateInitializer checks the configuration of the built-in
+// initializers, which create no individuals when it is invalid. Other
+// initializers are not checked.
+func validateInitializer(initializer Initializer) error {
+	if v, ok := initializer.(interface{ validate() error }); ok {
+		return v.validate()
+	}
+	return nil
+}
+
+// initialChromosomes calls initializer and checks it returned size
+// non-nil chromosomes.
+func initialChromosomes(initializer Initializer, size int, rng *rand.Rand) ([]Chromosome, error) {
+	population := initializer.Initialize(size, rng)
+	if len(population) != size {
+		return nil, fmt.Errorf("initializer returned %d individuals, need %d", len(population), size)
+	}
+	for i, c := range population {
+		if c == nil {
+			return nil, fmt.Errorf("initializer returned nil at index %d", i)
+		}
+	}
+	return population, nil
+}
+
+// initialMembers creates size unevaluated members with initializer.
+func (e *Engine[T]) initialMembers(initializer Initializer, size int) ([]*member[T], error) {
+	population, err := initialChromosomes(initializer, size, e.config.rng)
+	if err != nil {
+		return nil, err
+	}
+	members := make([]*member[T], size)
+	for i, c := range population {
+		value, ok := e.individualFrom(c)
+		if !ok {
+			return nil, fmt.Errorf("initializer returned %T at index %d, which is not a %T", c, i, value)
+		}
+		members[i] = &member[T]{value: value}
+	}
+	return members, nil
+}
+
+// ShuffleInitializer creates chromosomes for permutation problems from
+// uniformly random orderings of Length elements.
+type ShuffleInitializer struct {
+	// Length is the number of elements in each permutation.
+	Length int
+
+	// Build creates a chromosome from an ordering of 0..Length-1.
+	Build func(order []int) Chromosome
+}
+
+// Initialize implements Initializer. It returns nil if Length is not
+// positive or Build is nil.
+func (s ShuffleInitializer) Initialize(size int, rng *rand.Rand) []Chromosome {
+	if s.validate() != nil {
+		return nil
+	}
+	population := make([]Chromosome, size)
+	for i := range population {
+		population[i] = s.Build(rng.Perm(s.Length))
+	}
+	return population
+}
+
+// validate checks Length and Build.
+func (s ShuffleInitializer) validate() error {
+	if s.Length < 1 {
+		return fmt.Errorf("ShuffleInitializer length must be at least 1, got %d", s.Length)
+	}
+	if s.Build == nil {
+		return fmt.Errorf("ShuffleInitializer Build cannot be nil")
+	}
+	return nil
+}
+
+// NearestNeighbourInitializer creates chromosomes for permutation problems
+// with the nearest-neighbour heuristic: each ordering starts at a random
+// element and repeatedly moves to the closest element not yet visited. The
+// orderings are short tours that give the GA a head start, but there are at
+// most Length distinct ones, so mix them with random orderings using
+// MixedInitializer.
+type NearestNeighbourInitializer struct {
+	// Length is the number of elements in each permutation.
+	Length int
+
+	// Distance returns the distance between elements i and j.
+	Distance func(i, j int) float64
+
+	// Build creates a chromosome from an ordering of 0..Length-1.
+	Build func(order []int) Chromosome
+}
+
+// Initialize implements Initializer. It returns nil if Length is not
+// positive or Distance or Build is nil.
+func (n NearestNeighbourInitializer) Initialize(size int, rng *rand.Rand) []Chromosome {
+	if n.validate() != nil {
+		return nil
+	}
+	population := make([]Chromosome, size)
+	for i := range population {
+		population[i] = n.Build(n.tour(rng.Intn(n.Length)))
+	}
+	return population
+}
+
+// validate checks Length, Distance and Build.
+func (n NearestNeighbourInitializer) validate() error {
+	if n.Length < 1 {
+		return fmt.Errorf("NearestNeighbourInitializer length must be at least 1, got %d", n.Length)
+	}
+	if n.Distance == nil {
+		return fmt.Errorf("NearestNeighbourInitializer Distance cannot be nil")
+	}
+	if n.Build == nil {
+		return fmt.Errorf("NearestNeighbourInitializer Build cannot be nil")
+	}
+	return nil
+}
+
+// tour returns the nearest-neighbour ordering starting at start.
+func (n NearestNeighbourInitializer) tour(start int) []int {
+	order := make([]int, 0, n.Length)
+	visited := make([]bool, n.Length)
+	for current := start; ; {
+		order = append(order, current)
+		visited[current] = true
+		if len(order) == n.Length {
+			return order
+		}
+
+		next, closest := -1, math.Inf(1)
+		for j := range visited {
+			if visited[j] {
+				continue
+			}
+			if d := n.Distance(current, j); next < 0 || d < closest {
+				next, closest = j, d
+			}
+		}
+		current = next
+	}
+}
+
+// MixedInitializer seeds a fraction of the population with a heuristic and
+// fills the rest randomly, so the GA starts from good solutions without
+// losing diversity.
+type MixedInitializer struct {
+	// Heuristic creates the first round(HeuristicFraction * size)
+	// individuals and Random the rest.
+	Heuristic Initializer
+	Random    Initializer
+
+	// HeuristicFraction is the fraction of the population, between 0 and 1,
+	// created by Heuristic.
+	HeuristicFraction float64
+}
+
+// Initialize implements Initializer. It returns nil if HeuristicFraction
+// is NaN or the initializer it needs is nil or invalid.
+func (m MixedInitializer) Initialize(size int, rng *rand.Rand) []Chromosome {
+	if m.validate() != nil {
+		return nil
+	}
+	heuristic := int(math.Round(float64(size) * math.Max(0, math.Min(1, m.HeuristicFraction))))
+	population := make([]Chromosome, 0, size)
+	if heuristic > 0 {
+		population = append(population, m.Heuristic.Initialize(heuristic, rng)...)
+	}
+	if size > heuristic {
+		population = append(population, m.Random.Initialize(size-heuristic, rng)...)
+	}
+	return population
+}
+
+// validate checks HeuristicFraction and the initializers it selects.
+func (m MixedInitializer) validate() error {
+	if math.IsNaN(m.HeuristicFraction) {
+		return fmt.Errorf("MixedInitializer HeuristicFraction cannot be NaN")
+	}
+	if m.HeuristicFraction > 0 {
+		if m.Heuristic == nil {
+			return fmt.Errorf("MixedInitializer Heuristic cannot be nil with HeuristicFraction %g", m.HeuristicFraction)
+		}
+		if err := validateInitializer(m.Heuristic); err != nil {
+			return fmt.Errorf("MixedInitializer Heuristic: %w", err)
+		}
+	}
+	if m.HeuristicFraction < 1 {
+		if m.Random == nil {
+			return fmt.Errorf("MixedInitializer Random cannot be nil with HeuristicFraction %g", m.HeuristicFraction)
+		}
+		if err := validateInitializer(m.Random); err != nil {
+			return fmt.Errorf("MixedInitializer Random: %w", err)
+		}
+	}
+	return nil
+}
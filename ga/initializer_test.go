@@ -0,0 +1,183 @@
+package ga
+
+import (
+	"context"
+	"math"
+	"math/rand"
+	"sort"
+	"strings"
+	"testing"
+)
+
+// circleCities places n cities evenly on a circle, so the optimal tour visits
+// them in order
+func circleCities(n int) []City {
+	cities := make([]City, n)
+	for i := range cities {
+		angle := float64(i) * 2 * math.Pi / float64(n)
+		cities[i] = City{Name: string(rune('A' + i)), X: 10 * math.Cos(angle), Y: 10 * math.Sin(angle)}
+	}
+	return cities
+}
+
+// TestShuffleInitializer verifies random orderings are permutations of all
+// cities and reproducible from the seed
+func TestShuffleInitializer(t *testing.T) {
+	cities := circleCities(8)
+	initializer := ShuffleInitializer{Length: len(cities), Build: TSPRoutes(cities)}
+
+	population := initializer.Initialize(20, rand.New(rand.NewSource(1)))
+	again := initializer.Initialize(20, rand.New(rand.NewSource(1)))
+	distinct := make(map[string]bool)
+	for i, c := range population {
+		names := strings.Split(routeNames(c), "")
+		sort.Strings(names)
+		if strings.Join(names, "") != "ABCDEFGH" {
+			t.Fatalf("Route %d is not a permutation: %s", i, routeNames(c))
+		}
+		if routeNames(c) != routeNames(again[i]) {
+			t.Errorf("Route %d differs between runs with the same seed", i)
+		}
+		distinct[routeNames(c)] = true
+	}
+	if len(distinct) < 15 {
+		t.Errorf("Expected mostly distinct routes, got %d of 20", len(distinct))
+	}
+}
+
+// TestNearestNeighbourInitializer verifies the heuristic finds the optimal
+// tour around a circle from every starting city
+func TestNearestNeighbourInitializer(t *testing.T) {
+	cities := circleCities(10)
+	optimal := TSPRoutes(cities)([]int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}).Fitness()
+	initializer := NearestNeighbourInitializer{
+		Length:   len(cities),
+		Distance: TSPDistance(cities),
+		Build:    TSPRoutes(cities),
+	}
+
+	for i, c := range initializer.Initialize(20, rand.New(rand.NewSource(1))) {
+		if math.Abs(c.Fitness()-optimal) > 1e-12 {
+			t.Errorf("Route %d (%s) has fitness %f, expected the optimal %f", i, routeNames(c), c.Fitness(), optimal)
+		}
+	}
+}
+
+// TestMixedInitializer verifies the heuristic creates its fraction of the
+// population first and the random initializer the rest
+func TestMixedInitializer(t *testing.T) {
+	constant := func(fitness float64) Initializer {
+		return InitializerFunc(func(size int, rng *rand.Rand) []Chromosome {
+			population := make([]Chromosome, size)
+			for i := range population {
+				population[i] = &MockChromosome{fitness: fitness}
+			}
+			return population
+		})
+	}
+
+	tests := []struct {
+		fraction  float64
+		heuristic int
+	}{{0, 0}, {0.25, 3}, {1, 10}, {2, 10}}
+	for _, tt := range tests {
+		initializer := MixedInitializer{Heuristic: constant(1), Random: constant(0), HeuristicFraction: tt.fraction}
+		population := initializer.Initialize(10, rand.New(rand.NewSource(1)))
+		if len(population) != 10 {
+			t.Fatalf("Fraction %g: expected 10 individuals, got %d", tt.fraction, len(population))
+		}
+		for i, c := range population {
+			if want := i < tt.heuristic; (c.Fitness() == 1) != want {
+				t.Errorf("Fraction %g: individual %d heuristic = %v, expected %v", tt.fraction, i, !want, want)
+			}
+		}
+	}
+}
+
+// TestWithInitializer verifies the GA creates its population with its own
+// seeded RNG, so runs with the same seed are identical
+func TestWithInitializer(t *testing.T) {
+	cities := circleCities(8)
+	run := func() *GA {
+		algorithm := New(
+			WithInitializer(ShuffleInitializer{Length: len(cities), Build: TSPRoutes(cities)}, 30),
+			WithGenerations(10),
+			WithRandomSeed(5),
+		)
+		if _, err := algorithm.RunContext(context.Background()); err != nil {
+			t.Fatalf("Run failed: %v", err)
+		}
+		return algorithm
+	}
+
+	first, second := run(), run()
+	if len(first.Population) != 30 {
+		t.Fatalf("Expected a population of 30, got %d", len(first.Population))
+	}
+	for i := range first.Population {
+		if routeNames(first.Population[i]) != routeNames(second.Population[i]) {
+			t.Fatalf("Runs with the same seed differ at index %d", i)
+		}
+	}
+}
+
+// TestWithInitializerErrors verifies bad initializers are reported
+func TestWithInitializerErrors(t *testing.T) {
+	short := InitializerFunc(func(size int, rng *rand.Rand) []Chromosome {
+		return mockPopulation(1)
+	})
+	err := New(WithInitializer(short, 5)).Run()
+	if err == nil || !strings.Contains(err.Error(), "initializer returned 1 individuals, need 5") {
+		t.Errorf("Expected an error for a short initializer, got %v", err)
+	}
+
+	if err := New(WithInitializer(short, 0)).Validate(); err == nil {
+		t.Error("Expected an error for an empty initial population")
+	}
+	if err := New(WithInitializer(short, 5)).Validate(); err != nil {
+		t.Errorf("Expected the initializer to stand in for the population, got %v", err)
+	}
+}
+
+// TestInitializerConfigErrors verifies misconfigured built-in initializers
+// create nothing instead of panicking and are reported by the GA
+func TestInitializerConfigErrors(t *testing.T) {
+	cities := circleCities(6)
+	shuffle := ShuffleInitializer{Length: len(cities), Build: TSPRoutes(cities)}
+	tests := []struct {
+		name        string
+		initializer Initializer
+		want        string
+	}{
+		{"shuffle without length", ShuffleInitializer{Length: -1, Build: TSPRoutes(cities)}, "length must be at least 1"},
+		{"shuffle without build", ShuffleInitializer{Length: len(cities)}, "Build cannot be nil"},
+		{"nearest neighbour without length", NearestNeighbourInitializer{Distance: TSPDistance(cities), Build: TSPRoutes(cities)}, "length must be at least 1"},
+		{"nearest neighbour without distance", NearestNeighbourInitializer{Length: len(cities), Build: TSPRoutes(cities)}, "Distance cannot be nil"},
+		{"nearest neighbour without build", NearestNeighbourInitializer{Length: len(cities), Distance: TSPDistance(cities)}, "Build cannot be nil"},
+		{"mixed with NaN fraction", MixedInitializer{Heuristic: shuffle, Random: shuffle, HeuristicFraction: math.NaN()}, "cannot be NaN"},
+		{"mixed without heuristic", MixedInitializer{Random: shuffle, HeuristicFraction: 0.5}, "Heuristic cannot be nil"},
+		{"mixed without random", MixedInitializer{Heuristic: shuffle, HeuristicFraction: 0.5}, "Random cannot be nil"},
+		{"mixed with invalid heuristic", MixedInitializer{Heuristic: ShuffleInitializer{}, Random: shuffle, HeuristicFraction: 0.5}, "length must be at least 1"},
+	}
+	for _, tt := range tests {
+		if population := tt.initializer.Initialize(5, rand.New(rand.NewSource(1))); len(population) != 0 {
+			t.Errorf("%s: expected no individuals, got %d", tt.name, len(population))
+		}
+		if err := New(WithInitializer(tt.initializer, 5)).Run(); err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("%s: expected %q from Run, got %v", tt.name, tt.want, err)
+		}
+		err := New(
+			WithPopulation(mockPopulation(1, 2)),
+			WithConvergence(5, 0),
+			WithRestart(Restart{Initializer: tt.initializer}),
+		).Validate()
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("%s: expected %q for a restart, got %v", tt.name, tt.want, err)
+		}
+	}
+
+	valid := MixedInitializer{Heuristic: shuffle, HeuristicFraction: 1}
+	if err := New(WithInitializer(valid, 5)).Validate(); err != nil {
+		t.Errorf("Expected an unused Random initializer to be optional, got %v", err)
+	}
+}
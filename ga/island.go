@@ -0,0 +1,380 @@
+package ga
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Topology decides where migrants go in an IslandModel.
+//
+// THREAD SAFETY: The rng parameter MUST be used for all random operations
+// instead of the global math/rand.
+type Topology interface {
+	// Targets returns the indices of the islands that receive migrants from
+	// island out of islands in total.
+	Targets(island, islands int, rng *rand.Rand) []int
+}
+
+// RingTopology sends migrants from each island to the next one, wrapping
+// around from the last island to the first.
+type RingTopology struct{}
+
+// Targets returns the island after island.
+func (RingTopology) Targets(island, islands int, rng *rand.Rand) []int {
+	if islands < 2 {
+		return nil
+	}
+	return []int{(island + 1) % islands}
+}
+
+// FullyConnectedTopology sends migrants from each island to every other
+// island.
+type FullyConnectedTopology struct{}
+
+// Targets returns every island except island.
+func (FullyConnectedTopology) Targets(island, islands int, rng *rand.Rand) []int {
+	targets := make([]int, 0, islands)
+	for i := 0; i < islands; i++ {
+		if i != island {
+			targets = append(targets, i)
+		}
+	}
+	return targets
+}
+
+// RandomTopology sends migrants from each island to one other island chosen
+// at random at every migration.
+type RandomTopology struct{}
+
+// Targets returns one island other than island, chosen with rng.
+func (RandomTopology) Targets(island, islands int, rng *rand.Rand) []int {
+	if islands < 2 {
+		return nil
+	}
+	target := rng.Intn(islands - 1)
+	if target >= island {
+		target++
+	}
+	return []int{target}
+}
+
+// IslandModel runs several GA sub-populations ("islands") concurrently and
+// periodically exchanges their best individuals. Isolated islands explore
+// different regions of the search space, while migration spreads good
+// building blocks between them, which counters the premature convergence of
+// a single panmictic population.
+//
+// Each island is an ordinary GA with its own options, population and RNG, and
+// evolves for its own configured number of generations. Every
+// MigrationInterval generations the islands pause, each one sends clones of
+// its MigrationSize best individuals to the islands chosen by Topology, and
+// the receivers replace their worst individuals with them.
+type IslandModel struct {
+	Islands           []*GA
+	MigrationInterval int
+	MigrationSize     int
+	Topology          Topology
+	BestChromosome    Chromosome
+	rng               *rand.Rand
+	islandStats       []Stats
+}
+
+// NewIslandModel creates an island model over the given islands.
+//
+// Default settings:
+//   - Migration every 10 generations
+//   - 2 migrants per island
+//   - Ring topology
+//   - Random seed from current time
+//
+// Example:
+//
+//	islands := make([]*ga.GA, 4)
+//	for i := range islands {
+//	    islands[i] = ga.New(
+//	        ga.WithPopulation(newPopulation()),
+//	        ga.WithGenerations(500),
+//	        ga.WithRandomSeed(int64(i)),
+//	    )
+//	}
+//	model := ga.NewIslandModel(islands,
+//	    ga.WithMigration(25, 3),
+//	    ga.WithTopology(ga.FullyConnectedTopology{}),
+//	)
+//	result, err := model.RunContext(ctx)
+func NewIslandModel(islands []*GA, options ...func(*IslandModel)) *IslandModel {
+	m := &IslandModel{
+		Islands:           islands,
+		MigrationInterval: 10,
+		MigrationSize:     2,
+		Topology:          RingTopology{},
+		rng:               rand.New(rand.NewSource(time.Now().UnixNano())),
+	}
+	for _, option := range options {
+		option(m)
+	}
+	return m
+}
+
+// WithMigration sets how often migration happens and how many individuals
+// each island sends to each of its targets.
+func WithMigration(interval, size int) func(*IslandModel) {
+	return func(m *IslandModel) {
+		m.MigrationInterval = interval
+		m.MigrationSize = size
+	}
+}
+
+// WithTopology sets the migration topology.
+func WithTopology(topology Topology) func(*IslandModel) {
+	return func(m *IslandModel) {
+		m.Topology = topology
+	}
+}
+
+// WithMigrationSeed seeds the RNG used by random topologies, making the
+// migration pattern reproducible. Each island's own RNG is seeded through
+// its GA options.
+func WithMigrationSeed(seed int64) func(*IslandModel) {
+	return func(m *IslandModel) {
+		m.rng = rand.New(rand.NewSource(seed))
+	}
+}
+
+// Validate checks the island model and every island's configuration.
+func (m *IslandModel) Validate() error {
+	if len(m.Islands) == 0 {
+		return fmt.Errorf("island model needs at least one island")
+	}
+	if m.MigrationInterval < 1 {
+		return fmt.Errorf("migration interval must be at least 1, got %d", m.MigrationInterval)
+	}
+	if m.MigrationSize < 0 {
+		return fmt.Errorf("migration size cannot be negative, got %d", m.MigrationSize)
+	}
+	if m.Topology == nil {
+		return fmt.Errorf("topology cannot be nil")
+	}
+	for i, island := range m.Islands {
+		if island == nil {
+			return fmt.Errorf("island %d is nil", i)
+		}
+		if err := island.Validate(); err != nil {
+			return fmt.Errorf("island %d: %w", i, err)
+		}
+	}
+	return nil
+}
+
+// Run evolves all islands until each has run its configured number of
+// generations or converged, or until any island reaches its target fitness.
+func (m *IslandModel) Run() error {
+	_, err := m.RunContext(context.Background())
+	return err
+}
+
+// RunContext is like Run but stops early when ctx is cancelled or its
+// deadline expires. The returned Result carries the best chromosome across
+// all islands; its Generations is the longest island run and Evaluations the
+// total over all islands. Per-island statistics are available from
+// IslandStats. When the run ends, every island's Population,
+// BestChromosome and HallOfFame reflect its final state.
+func (m *IslandModel) RunContext(ctx context.Context) (Result, error) {
+	for i, island := range m.Islands {
+		if island == nil {
+			continue
+		}
+		if err := island.initialize(); err != nil {
+			return Result{}, fmt.Errorf("island %d: %w", i, err)
+		}
+	}
+	if err := m.Validate(); err != nil {
+		return Result{}, fmt.Errorf("invalid island model configuration: %w", err)
+	}
+
+	start := time.Now()
+	n := len(m.Islands)
+	engines := make([]*Engine[chromosomeIndividual], n)
+	states := make([]*runState[chromosomeIndividual], n)
+	m.islandStats = make([]Stats, n)
+	for i, island := range m.Islands {
+		engines[i] = island.engine()
+		states[i] = engines[i].newRunState()
+	}
+
+	done := make([]bool, n)
+	errs := make([]error, n)
+	bestFitness, bestViolation := math.Inf(-1), math.Inf(1)
+	reason := StopMaxGenerations
+
+	for epochEnd := m.MigrationInterval; ; epochEnd += m.MigrationInterval {
+		// Evolve every active island up to the end of the epoch. Islands
+		// share nothing, so they can run concurrently.
+		var wg sync.WaitGroup
+		for i := range engines {
+			if done[i] {
+				continue
+			}
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				m.islandStats[i], errs[i] = engines[i].run(ctx, states[i], epochEnd)
+			}(i)
+		}
+		wg.Wait()
+
+		// Track the global best and retire finished islands
+		active := 0
+		for i, e := range engines {
+			if e.hasBest && (m.BestChromosome == nil ||
+				ranksAbove(states[i].bestFitness, states[i].bestViolation, bestFitness, bestViolation)) {
+				m.BestChromosome = e.best.Chromosome
+				bestFitness, bestViolation = states[i].bestFitness, states[i].bestViolation
+			}
+			switch m.islandStats[i].StopReason {
+			case StopNone:
+				if errs[i] == nil {
+					active++
+				}
+			case StopTargetReached:
+				reason = StopTargetReached
+				done[i] = true
+			default:
+				done[i] = true
+			}
+		}
+
+		var err error
+		for i := range errs {
+			if errs[i] != nil {
+				err = fmt.Errorf("island %d: %w", i, errs[i])
+				reason = StopNone
+				if ctx.Err() != nil {
+					reason = StopCancelled
+				}
+				break
+			}
+		}
+		if err != nil || reason == StopTargetReached || active == 0 {
+			if err == nil && reason != StopTargetReached && m.allConverged() {
+				reason = StopConverged
+			}
+			// Islands still paused at the barrier stop with the model
+			for i, e := range engines {
+				if !done[i] && errs[i] == nil {
+					m.islandStats[i] = e.end(states[i], reason, err)
+				}
+			}
+			m.finish(engines)
+			return m.result(reason, time.Since(start)), err
+		}
+
+		m.migrate(engines, states, done)
+	}
+}
+
+// Best returns the best chromosome found on any island.
+func (m *IslandModel) Best() Chromosome {
+	return m.BestChromosome
+}
+
+// IslandStats returns the statistics of each island from the last run, in
+// island order.
+func (m *IslandModel) IslandStats() []Stats {
+	return m.islandStats
+}
+
+// allConverged reports whether every island stopped by convergence.
+func (m *IslandModel) allConverged() bool {
+	for _, stats := range m.islandStats {
+		if stats.StopReason != StopConverged {
+			return false
+		}
+	}
+	return true
+}
+
+// migrate sends clones of each active island's best individuals to its
+// active topology targets, where they replace the worst individuals. The
+// island's own best individual is never replaced. Islands that are done
+// neither send nor receive migrants, so they keep the state they finished
+// with.
+func (m *IslandModel) migrate(engines []*Engine[chromosomeIndividual], states []*runState[chromosomeIndividual], done []bool) {
+	if m.MigrationSize == 0 {
+		return
+	}
+
+	// Score, penalize and sort every island like the run loop does, so
+	// emigrants are its current best. The scores are cached, so the next
+	// generation does not re-evaluate them.
+	for i, e := range engines {
+		if done[i] {
+			continue
+		}
+		states[i].stats.Evaluations += e.evaluate(states[i].population)
+		e.applyPenalty(states[i].population, states[i].penaltyWeight)
+		sortByFitness(states[i].population)
+	}
+
+	// Collect migrants from the pre-migration populations so the outcome
+	// does not depend on island order.
+	incoming := make([][]*member[chromosomeIndividual], len(engines))
+	for i := range engines {
+		if done[i] {
+			continue
+		}
+		population := states[i].population
+		k := m.MigrationSize
+		if k > len(population) {
+			k = len(population)
+		}
+		for _, target := range m.Topology.Targets(i, len(engines), m.rng) {
+			if done[target] {
+				continue
+			}
+			for _, emigrant := range population[:k] {
+				incoming[target] = append(incoming[target], emigrant.clone())
+			}
+		}
+	}
+
+	for i, migrants := range incoming {
+		population := states[i].population
+		sort.SliceStable(migrants, func(a, b int) bool {
+			return Fitter(migrants[a], migrants[b])
+		})
+		if limit := len(population) - 1; len(migrants) > limit {
+			migrants = migrants[:limit]
+		}
+		copy(population[len(population)-len(migrants):], migrants)
+		for j, mem := range population {
+			engines[i].Population[j] = mem.value
+		}
+	}
+}
+
+// finish copies each engine's final state back into its island.
+func (m *IslandModel) finish(engines []*Engine[chromosomeIndividual]) {
+	for i, e := range engines {
+		m.Islands[i].collect(e)
+	}
+}
+
+// result aggregates the island statistics.
+func (m *IslandModel) result(reason StopReason, duration time.Duration) Result {
+	result := Result{Best: m.BestChromosome}
+	for _, stats := range m.islandStats {
+		if stats.Generations > result.Generations {
+			result.Generations = stats.Generations
+		}
+		result.Evaluations += stats.Evaluations
+	}
+	result.StopReason = reason
+	result.Duration = duration
+	return result
+}
@@ -0,0 +1,262 @@
+package ga
+
+import (
+	"context"
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+func newIslands(count int) []*GA {
+	islands := make([]*GA, count)
+	for i := range islands {
+		islands[i] = New(
+			WithPopulation(newTSPPopulation(20, 10, int64(i+1))),
+			WithGenerations(40),
+			WithMutationRate(0.3),
+			WithRandomSeed(int64(100+i)),
+		)
+	}
+	return islands
+}
+
+// TestIslandModelBestAcrossIslands verifies the model reports the best
+// chromosome of all islands and aggregates their statistics
+func TestIslandModelBestAcrossIslands(t *testing.T) {
+	model := NewIslandModel(newIslands(3), WithMigration(5, 2), WithMigrationSeed(1))
+	result, err := model.RunContext(context.Background())
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	if result.StopReason != StopMaxGenerations {
+		t.Errorf("Expected StopMaxGenerations, got %v", result.StopReason)
+	}
+	if result.Generations != 40 {
+		t.Errorf("Expected 40 generations, got %d", result.Generations)
+	}
+
+	evaluations := 0
+	for i, island := range model.Islands {
+		if island.BestChromosome == nil {
+			t.Fatalf("Island %d has no best chromosome", i)
+		}
+		if island.BestChromosome.Fitness() > result.Best.Fitness() {
+			t.Errorf("Island %d best %v beats the model best %v",
+				i, island.BestChromosome.Fitness(), result.Best.Fitness())
+		}
+		evaluations += model.IslandStats()[i].Evaluations
+	}
+	if result.Evaluations != evaluations {
+		t.Errorf("Expected %d evaluations, got %d", evaluations, result.Evaluations)
+	}
+}
+
+// TestIslandModelReproducibleWithSeed verifies seeded island runs produce
+// identical results despite running the islands concurrently
+func TestIslandModelReproducibleWithSeed(t *testing.T) {
+	run := func() (string, float64) {
+		model := NewIslandModel(newIslands(4),
+			WithMigration(3, 1),
+			WithTopology(RandomTopology{}),
+			WithMigrationSeed(7),
+		)
+		result, err := model.RunContext(context.Background())
+		if err != nil {
+			t.Fatalf("Run failed: %v", err)
+		}
+		return routeNames(result.Best), result.Best.Fitness()
+	}
+
+	route1, fitness1 := run()
+	route2, fitness2 := run()
+	if route1 != route2 || fitness1 != fitness2 {
+		t.Errorf("Seeded island runs differ: %s (%v) vs %s (%v)", route1, fitness1, route2, fitness2)
+	}
+}
+
+// TestMigrationReplacesWorst verifies migrants replace the receiver's worst
+// individuals but never its best
+func TestMigrationReplacesWorst(t *testing.T) {
+	islands := []*GA{
+		New(WithPopulation(mockPopulation(10, 9, 8)), WithGenerations(1)),
+		New(WithPopulation(mockPopulation(3, 2, 1)), WithGenerations(1)),
+	}
+	model := NewIslandModel(islands, WithMigration(1, 5))
+	engines := make([]*Engine[chromosomeIndividual], len(islands))
+	states := make([]*runState[chromosomeIndividual], len(islands))
+	for i, island := range islands {
+		engines[i] = island.engine()
+		states[i] = engines[i].newRunState()
+	}
+
+	model.migrate(engines, states, make([]bool, len(islands)))
+
+	fitness := func(state *runState[chromosomeIndividual]) []float64 {
+		values := make([]float64, len(state.population))
+		for i, m := range state.population {
+			values[i] = m.fitness
+		}
+		return values
+	}
+	if got, want := fitness(states[1]), []float64{3, 10, 9}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected second island %v, got %v", want, got)
+	}
+	if got, want := fitness(states[0]), []float64{10, 3, 2}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected first island %v, got %v", want, got)
+	}
+}
+
+// TestMigrationRanksPenalizedFitness verifies emigrants are chosen on the
+// penalized fitness the run loop ranks by, not by Deb's rules on raw fitness
+func TestMigrationRanksPenalizedFitness(t *testing.T) {
+	bounded := func(xs ...int) []Chromosome {
+		population := make([]Chromosome, len(xs))
+		for i, x := range xs {
+			population[i] = &boundedChromosome{x: x, limit: 10}
+		}
+		return population
+	}
+	// With weight 1, x = 12 scores 12 - 2 = 10 and ranks first; by Deb's
+	// rules alone it would rank last.
+	islands := []*GA{
+		New(WithPopulation(bounded(5, 12, 8)), WithGenerations(1), WithAdaptivePenalty(AdaptivePenalty{Initial: 1})),
+		New(WithPopulation(bounded(1, 2, 3)), WithGenerations(1), WithAdaptivePenalty(AdaptivePenalty{Initial: 1})),
+	}
+	model := NewIslandModel(islands, WithMigration(1, 1))
+	engines := make([]*Engine[chromosomeIndividual], len(islands))
+	states := make([]*runState[chromosomeIndividual], len(islands))
+	for i, island := range islands {
+		engines[i] = island.engine()
+		states[i] = engines[i].newRunState()
+	}
+
+	model.migrate(engines, states, make([]bool, len(islands)))
+
+	migrant := states[1].population[len(states[1].population)-1]
+	if x := migrant.value.Chromosome.(*boundedChromosome).x; x != 12 {
+		t.Errorf("Expected the penalized best x = 12 to migrate, got x = %d", x)
+	}
+}
+
+// TestFinishedIslandKeepsFinalState verifies an island that stopped early
+// neither sends nor receives migrants afterwards
+func TestFinishedIslandKeepsFinalState(t *testing.T) {
+	islands := []*GA{
+		New(WithPopulation(mockPopulation(1, 2, 3)), WithGenerations(3), WithMutationRate(0)),
+		New(WithPopulation(mockPopulation(30, 30, 30)), WithGenerations(9), WithMutationRate(0)),
+	}
+	model := NewIslandModel(islands, WithMigration(3, 3))
+	result, err := model.RunContext(context.Background())
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+
+	for _, c := range islands[0].Population {
+		if c.Fitness() > 3 {
+			t.Errorf("Expected the finished island to keep its own population, found fitness %f", c.Fitness())
+		}
+	}
+	if islands[0].Best().Fitness() != 3 {
+		t.Errorf("Expected the finished island's best to be 3, got %f", islands[0].Best().Fitness())
+	}
+	evaluations := 0
+	for _, stats := range model.IslandStats() {
+		evaluations += stats.Evaluations
+	}
+	if evaluations != result.Evaluations {
+		t.Errorf("Expected %d evaluations across islands, got %d", result.Evaluations, evaluations)
+	}
+}
+
+// TestIslandHallOfFame verifies every island keeps its own hall of fame
+func TestIslandHallOfFame(t *testing.T) {
+	islands := newIslands(2)
+	for _, island := range islands {
+		WithHallOfFame(5)(island)
+	}
+	model := NewIslandModel(islands, WithMigration(5, 2), WithMigrationSeed(1))
+	if err := model.Run(); err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+
+	for i, island := range islands {
+		fame := island.HallOfFame()
+		if len(fame) != 5 {
+			t.Fatalf("Island %d: expected 5 hall of fame entries, got %d", i, len(fame))
+		}
+		if fame[0].Fitness() != island.Best().Fitness() {
+			t.Errorf("Island %d: expected the best first, got %f and %f", i, fame[0].Fitness(), island.Best().Fitness())
+		}
+	}
+}
+
+// TestIslandModelEndsActiveIslands verifies islands still running when
+// another island reaches the target are told the run has ended
+func TestIslandModelEndsActiveIslands(t *testing.T) {
+	recorders := []*eventRecorder{{}, {}}
+	islands := []*GA{
+		New(WithPopulation(mockPopulation(1, 2, 3)), WithGenerations(20), WithTargetFitness(3), WithObserver(recorders[0])),
+		New(WithPopulation(mockPopulation(1, 2, 3)), WithGenerations(20), WithObserver(recorders[1])),
+	}
+	model := NewIslandModel(islands, WithMigration(5, 1))
+	result, err := model.RunContext(context.Background())
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	if result.StopReason != StopTargetReached {
+		t.Fatalf("Expected the model to stop at the target, got %v", result.StopReason)
+	}
+
+	for i, recorder := range recorders {
+		if got := recorder.count(RunEnded); got != 1 {
+			t.Errorf("Island %d: expected one run ended event, got %d", i, got)
+		}
+		if got := model.IslandStats()[i].StopReason; got != StopTargetReached {
+			t.Errorf("Island %d: expected StopTargetReached, got %v", i, got)
+		}
+	}
+	last := recorders[1].events[len(recorders[1].events)-1]
+	if last.Kind != RunEnded || last.Run.StopReason != StopTargetReached || last.Generation != 4 {
+		t.Errorf("Expected the active island to end after generation 4, got %s at %d (%s)", last.Kind, last.Generation, last.Run.StopReason)
+	}
+}
+
+// TestTopologyTargets verifies the built-in topologies
+func TestTopologyTargets(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+
+	if got := (RingTopology{}).Targets(3, 4, rng); !reflect.DeepEqual(got, []int{0}) {
+		t.Errorf("Ring: expected [0], got %v", got)
+	}
+	if got := (FullyConnectedTopology{}).Targets(1, 4, rng); !reflect.DeepEqual(got, []int{0, 2, 3}) {
+		t.Errorf("Fully connected: expected [0 2 3], got %v", got)
+	}
+	for i := 0; i < 100; i++ {
+		got := (RandomTopology{}).Targets(2, 4, rng)
+		if len(got) != 1 || got[0] == 2 || got[0] < 0 || got[0] >= 4 {
+			t.Fatalf("Random: invalid targets %v", got)
+		}
+	}
+	if got := (RingTopology{}).Targets(0, 1, rng); len(got) != 0 {
+		t.Errorf("Ring with one island: expected no targets, got %v", got)
+	}
+}
+
+// TestIslandModelValidate verifies invalid island models are rejected
+func TestIslandModelValidate(t *testing.T) {
+	tests := []struct {
+		name  string
+		model *IslandModel
+	}{
+		{"no islands", NewIslandModel(nil)},
+		{"zero interval", NewIslandModel(newIslands(2), WithMigration(0, 1))},
+		{"negative size", NewIslandModel(newIslands(2), WithMigration(5, -1))},
+		{"nil topology", NewIslandModel(newIslands(2), WithTopology(nil))},
+		{"invalid island", NewIslandModel([]*GA{New()})},
+	}
+	for _, tt := range tests {
+		if err := tt.model.Validate(); err == nil {
+			t.Errorf("%s: expected validation error", tt.name)
+		}
+	}
+}
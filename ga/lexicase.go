@@ -0,0 +1,171 @@
+package ga
+
+import (
+	"math"
+	"math/rand"
+	"sort"
+)
+
+// CaseFitness is implemented by chromosomes (or Engine individuals) whose
+// quality is measured on several test cases, such as the training points of
+// a symbolic regression. Higher values are better, as with Fitness.
+// Lexicase selection uses the individual scores instead of collapsing them
+// into a single number.
+type CaseFitness interface {
+	// CaseFitness returns one score per test case. Every individual should
+	// return the cases in the same order.
+	CaseFitness() []float64
+}
+
+// EpsilonLexicaseSelector implements ε-lexicase selection. For each parent
+// it shuffles the test cases with the provided RNG and, case by case, keeps
+// only the candidates within Epsilon of the best score on that case, until
+// one candidate remains or the cases run out; ties are broken at random.
+// Because every selection event orders the cases differently, specialists
+// that excel on a few hard cases survive alongside generalists.
+//
+// Individuals that do not implement CaseFitness are treated as having a
+// single case, their Fitness. Missing cases and NaN scores count as -Inf.
+// Case scores are computed once per generation in Prepare, so an
+// EpsilonLexicaseSelector must not be shared by concurrently running GAs.
+type EpsilonLexicaseSelector struct {
+	// Epsilon is the tolerance on every case. If 0, each case uses the
+	// median absolute deviation of its scores across the population,
+	// recomputed every generation (semi-dynamic ε-lexicase). A negative
+	// value disables the tolerance (plain lexicase).
+	Epsilon float64
+
+	population []Chromosome
+	cases      [][]float64
+	epsilons   []float64
+	cache      map[Chromosome][]float64
+}
+
+// Prepare computes the case scores of population and the per-case
+// tolerances. Scores of individuals seen in the previous call are reused.
+func (s *EpsilonLexicaseSelector) Prepare(population []Chromosome) {
+	cache := make(map[Chromosome][]float64, len(population))
+	s.population = population
+	s.cases = make([][]float64, len(population))
+	numCases := 0
+	for i, c := range population {
+		cases, ok := s.cache[c]
+		if !ok {
+			cases = caseFitness(c)
+		}
+		cache[c] = cases
+		s.cases[i] = cases
+		if len(cases) > numCases {
+			numCases = len(cases)
+		}
+	}
+	s.cache = cache
+
+	s.epsilons = make([]float64, numCases)
+	if s.Epsilon != 0 {
+		for j := range s.epsilons {
+			s.epsilons[j] = math.Max(s.Epsilon, 0)
+		}
+		return
+	}
+	scores := make([]float64, 0, len(population))
+	for j := range s.epsilons {
+		scores = scores[:0]
+		for i := range population {
+			if score := s.score(i, j); !math.IsInf(score, 0) {
+				scores = append(scores, score)
+			}
+		}
+		s.epsilons[j] = medianAbsoluteDeviation(scores)
+	}
+}
+
+// Select returns two parents, each chosen by an independent lexicase event.
+//
+// THREAD SAFETY: Uses the provided rng parameter instead of global math/rand.
+func (s *EpsilonLexicaseSelector) Select(population []Chromosome, rng *rand.Rand) []Chromosome {
+	if len(population) == 0 {
+		return []Chromosome{}
+	}
+	if len(s.population) != len(population) || &s.population[0] != &population[0] {
+		s.Prepare(population)
+	}
+
+	order := make([]int, len(s.epsilons))
+	candidates := make([]int, 0, len(population))
+	parents := make([]Chromosome, 2)
+	for p := range parents {
+		for j := range order {
+			order[j] = j
+		}
+		rng.Shuffle(len(order), func(a, b int) { order[a], order[b] = order[b], order[a] })
+
+		candidates = candidates[:0]
+		for i := range population {
+			candidates = append(candidates, i)
+		}
+		for _, j := range order {
+			if len(candidates) == 1 {
+				break
+			}
+			best := math.Inf(-1)
+			for _, i := range candidates {
+				best = math.Max(best, s.score(i, j))
+			}
+			// score == best keeps the leaders even if best-epsilon is NaN
+			survivors := candidates[:0]
+			for _, i := range candidates {
+				if score := s.score(i, j); score >= best-s.epsilons[j] || score == best {
+					survivors = append(survivors, i)
+				}
+			}
+			candidates = survivors
+		}
+		parents[p] = population[candidates[rng.Intn(len(candidates))]]
+	}
+	return parents
+}
+
+// score returns individual i's score on case j.
+func (s *EpsilonLexicaseSelector) score(i, j int) float64 {
+	cases := s.cases[i]
+	if j >= len(cases) || math.IsNaN(cases[j]) {
+		return math.Inf(-1)
+	}
+	return cases[j]
+}
+
+// caseFitness returns the case scores of c, looking through engine members to
+// the user's individual, or its Fitness as a single case.
+func caseFitness(c Chromosome) []float64 {
+	var individual any = c
+	if m, ok := c.(interface{ individual() any }); ok {
+		individual = m.individual()
+	}
+	if cf, ok := individual.(CaseFitness); ok {
+		return cf.CaseFitness()
+	}
+	return []float64{c.Fitness()}
+}
+
+// medianAbsoluteDeviation returns the median of the absolute deviations of
+// values from their median, or 0 for no values. values is reordered.
+func medianAbsoluteDeviation(values []float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	median := func(v []float64) float64 {
+		sort.Float64s(v)
+		n := len(v)
+		if n%2 == 1 {
+			return v[n/2]
+		}
+		return (v[n/2-1] + v[n/2]) / 2
+	}
+
+	center := median(values)
+	for i, v := range values {
+		values[i] = math.Abs(v - center)
+	}
+	return median(values)
+}
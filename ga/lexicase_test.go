@@ -0,0 +1,121 @@
+package ga
+
+import (
+	"math"
+	"testing"
+)
+
+// caseChromosome scores itself on a fixed set of test cases.
+type caseChromosome struct {
+	cases []float64
+}
+
+func (c *caseChromosome) Fitness() float64 {
+	total := 0.0
+	for _, score := range c.cases {
+		total += score
+	}
+	return total
+}
+
+func (c *caseChromosome) CaseFitness() []float64 { return c.cases }
+
+func (c *caseChromosome) Crossover(other Chromosome) Chromosome { return c.Clone() }
+
+func (c *caseChromosome) Mutate() {}
+
+func (c *caseChromosome) Clone() Chromosome {
+	return &caseChromosome{cases: append([]float64(nil), c.cases...)}
+}
+
+func casePopulation(cases ...[]float64) []Chromosome {
+	population := make([]Chromosome, len(cases))
+	for i, c := range cases {
+		population[i] = &caseChromosome{cases: c}
+	}
+	return population
+}
+
+// TestLexicaseFavoursSpecialists verifies plain lexicase picks the
+// specialists and never a generalist that is best on no case
+func TestLexicaseFavoursSpecialists(t *testing.T) {
+	population := casePopulation([]float64{10, 0}, []float64{0, 10}, []float64{6, 6})
+	counts := selectionCounts(&EpsilonLexicaseSelector{Epsilon: -1}, population, 1000, 1)
+	if counts[2] != 0 {
+		t.Errorf("Expected the generalist never to be chosen, got %v", counts)
+	}
+	for j := 0; j < 2; j++ {
+		if share := float64(counts[j]) / 2000; math.Abs(share-0.5) > 0.05 {
+			t.Errorf("Expected specialist %d about half the time, got %.3f", j, share)
+		}
+	}
+}
+
+// TestLexicaseEpsilon verifies candidates within epsilon of the best survive
+func TestLexicaseEpsilon(t *testing.T) {
+	population := casePopulation([]float64{10, 0}, []float64{0, 10}, []float64{6, 6})
+	counts := selectionCounts(&EpsilonLexicaseSelector{Epsilon: 5}, population, 1000, 2)
+	if counts[2] == 0 {
+		t.Errorf("Expected the generalist to survive with epsilon 5, got %v", counts)
+	}
+
+	// Automatic epsilon uses each case's median absolute deviation
+	selector := &EpsilonLexicaseSelector{}
+	selector.Prepare(casePopulation([]float64{1, 0}, []float64{2, 0}, []float64{4, 0}, []float64{8, 0}))
+	if want := []float64{1.5, 0}; selector.epsilons[0] != want[0] || selector.epsilons[1] != want[1] {
+		t.Errorf("Expected epsilons %v, got %v", want, selector.epsilons)
+	}
+}
+
+// TestLexicaseFallsBackToFitness verifies chromosomes without CaseFitness
+// are treated as a single case and missing or NaN cases count as -Inf
+func TestLexicaseFallsBackToFitness(t *testing.T) {
+	counts := selectionCounts(&EpsilonLexicaseSelector{Epsilon: -1}, mockPopulation(1, 3, 2), 100, 1)
+	if counts[1] != 200 {
+		t.Errorf("Expected the fittest to win every event, got %v", counts)
+	}
+
+	population := casePopulation([]float64{5}, []float64{math.NaN(), 9}, []float64{4, 1})
+	counts = selectionCounts(&EpsilonLexicaseSelector{Epsilon: -1}, population, 500, 2)
+	if counts[0] == 0 || counts[1] == 0 || counts[2] != 0 {
+		t.Errorf("Unexpected counts with missing and NaN cases: %v", counts)
+	}
+}
+
+// TestCaseFitnessThroughMembers verifies the selector sees the case scores
+// of the chromosome behind an engine member
+func TestCaseFitnessThroughMembers(t *testing.T) {
+	c := &caseChromosome{cases: []float64{1, 2, 3}}
+	m := &member[chromosomeIndividual]{value: chromosomeIndividual{c}}
+	if got := caseFitness(m); len(got) != 3 || got[2] != 3 {
+		t.Errorf("Expected case scores through the member, got %v", got)
+	}
+
+	algorithm := New(
+		WithPopulation(casePopulation([]float64{1, 0}, []float64{0, 1}, []float64{1, 1})),
+		WithGenerations(3),
+		WithSelector(&EpsilonLexicaseSelector{}),
+		WithRandomSeed(1),
+	)
+	if err := algorithm.Run(); err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+}
+
+// TestMedianAbsoluteDeviation verifies the epsilon statistic
+func TestMedianAbsoluteDeviation(t *testing.T) {
+	tests := []struct {
+		values []float64
+		want   float64
+	}{
+		{nil, 0},
+		{[]float64{3}, 0},
+		{[]float64{1, 1, 2, 2, 4, 6, 9}, 1},
+		{[]float64{1, 2, 4, 8}, 1.5},
+	}
+	for _, tt := range tests {
+		if got := medianAbsoluteDeviation(tt.values); got != tt.want {
+			t.Errorf("medianAbsoluteDeviation(%v) = %v, want %v", tt.values, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,91 @@
+package ga
+
+import (
+	"fmt"
+	"math/rand"
+)
+
+// MutationMode chooses what the mutation rate is the probability of.
+type MutationMode int
+
+const (
+	// PerIndividual flips one coin per offspring with the mutation rate and
+	// calls Mutate (or MutateRand) once if it comes up. This is the default.
+	PerIndividual MutationMode = iota
+
+	// PerGene visits every locus of an offspring and mutates it with the
+	// mutation rate, so an offspring of length L receives rate*L mutations
+	// on average. Individuals must implement GeneMutator.
+	PerGene
+)
+
+// String returns a human-readable name for the mutation mode.
+func (m MutationMode) String() string {
+	switch m {
+	case PerIndividual:
+		return "per individual"
+	case PerGene:
+		return "per gene"
+	default:
+		return fmt.Sprintf("MutationMode(%d)", int(m))
+	}
+}
+
+// GeneMutator is implemented by individuals that can mutate a single locus.
+// In PerGene mode the engine calls MutateGene for each locus that is chosen
+// for mutation; Mutate and MutateRand are not used.
+//
+// Example:
+//
+//	func (b *Bits) Len() int { return len(b.Genes) }
+//
+//	func (b *Bits) MutateGene(i int, rng *rand.Rand) {
+//	    b.Genes[i] = !b.Genes[i]
+//	}
+type GeneMutator interface {
+	// Len returns the number of loci in the genome.
+	Len() int
+
+	// MutateGene mutates locus i, using rng for all random decisions.
+	MutateGene(i int, rng *rand.Rand)
+}
+
+// WithMutationMode sets whether the mutation rate applies per individual
+// (the default) or per gene. Per-gene rates are typically much lower, around
+// 1/L for a genome of length L; a RateSchedule adapts the per-gene rate in
+// PerGene mode.
+//
+// Example:
+//
+//	ga.WithMutationMode(ga.PerGene),
+//	ga.WithMutationRate(0.01),
+func WithMutationMode(mode MutationMode) func(*GA) {
+	return func(ga *GA) {
+		ga.mutationMode = mode
+	}
+}
+
+// validateMutationMode checks that individual i supports the configured
+// mutation mode.
+func (ga *GA) validateMutationMode(i int, individual any) error {
+	if ga.mutationMode != PerGene {
+		return nil
+	}
+	if _, ok := individual.(GeneMutator); !ok {
+		return fmt.Errorf("per-gene mutation requires GeneMutator, individual at index %d is %T", i, individual)
+	}
+	return nil
+}
+
+// mutateGenes mutates each locus of g with probability rate and reports
+// whether any locus was mutated.
+func mutateGenes(g GeneMutator, rate float64, rng *rand.Rand) bool {
+	mutated := false
+	for i, n := 0, g.Len(); i < n; i++ {
+		if rng.Float64() < rate {
+			g.MutateGene(i, rng)
+			mutated = true
+		}
+	}
+	return mutated
+}
@@ -0,0 +1,157 @@
+package ga
+
+import (
+	"context"
+	"math/rand"
+	"strings"
+	"testing"
+)
+
+// bitChromosome maximizes the number of set bits and counts how it is
+// mutated.
+type bitChromosome struct {
+	genes          []bool
+	wholeMutations int
+	geneMutations  int
+}
+
+func (c *bitChromosome) Fitness() float64 {
+	ones := 0
+	for _, g := range c.genes {
+		if g {
+			ones++
+		}
+	}
+	return float64(ones)
+}
+
+func (c *bitChromosome) Crossover(other Chromosome) Chromosome { return c.Clone() }
+
+func (c *bitChromosome) Mutate() { c.wholeMutations++ }
+
+func (c *bitChromosome) Clone() Chromosome {
+	genes := make([]bool, len(c.genes))
+	copy(genes, c.genes)
+	return &bitChromosome{genes: genes}
+}
+
+func (c *bitChromosome) Len() int { return len(c.genes) }
+
+func (c *bitChromosome) MutateGene(i int, rng *rand.Rand) {
+	c.genes[i] = !c.genes[i]
+	c.geneMutations++
+}
+
+func newBitPopulation(size, length int) []Chromosome {
+	population := make([]Chromosome, size)
+	for i := range population {
+		population[i] = &bitChromosome{genes: make([]bool, length)}
+	}
+	return population
+}
+
+// TestMutateGenesRate verifies each locus is mutated with the given
+// probability
+func TestMutateGenesRate(t *testing.T) {
+	c := &bitChromosome{genes: make([]bool, 10000)}
+	if !mutateGenes(c, 0.05, rand.New(rand.NewSource(1))) {
+		t.Fatal("Expected some loci to be mutated")
+	}
+	if c.geneMutations < 400 || c.geneMutations > 600 {
+		t.Errorf("Expected about 500 mutated loci, got %d", c.geneMutations)
+	}
+
+	c = &bitChromosome{genes: make([]bool, 100)}
+	if mutateGenes(c, 0, rand.New(rand.NewSource(1))) || c.geneMutations != 0 {
+		t.Error("Expected no mutations at rate 0")
+	}
+}
+
+// TestPerGeneModeUsesMutateGene verifies the engine mutates individual loci
+// instead of calling Mutate in PerGene mode
+func TestPerGeneModeUsesMutateGene(t *testing.T) {
+	algorithm := New(
+		WithPopulation(newBitPopulation(10, 20)),
+		WithGenerations(5),
+		WithCrossoverRate(0),
+		WithMutationRate(1),
+		WithMutationMode(PerGene),
+		WithElitism(false),
+		WithRandomSeed(1),
+	)
+	if err := algorithm.Run(); err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+
+	for i, c := range algorithm.Population {
+		b := c.(*bitChromosome)
+		if b.wholeMutations != 0 {
+			t.Errorf("Individual %d: Mutate called %d times in PerGene mode", i, b.wholeMutations)
+		}
+		if b.geneMutations != 20 {
+			t.Errorf("Individual %d: expected all 20 loci mutated, got %d", i, b.geneMutations)
+		}
+	}
+}
+
+// TestPerGeneModeKeepsUnmutatedScores verifies offspring whose loci all
+// survive keep their cached fitness
+func TestPerGeneModeKeepsUnmutatedScores(t *testing.T) {
+	algorithm := New(
+		WithPopulation(newBitPopulation(10, 20)),
+		WithGenerations(5),
+		WithCrossoverRate(0),
+		WithMutationRate(0),
+		WithMutationMode(PerGene),
+	)
+	stats, err := algorithm.RunContext(context.Background())
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	if stats.Evaluations != 10 {
+		t.Errorf("Expected only the initial 10 evaluations, got %d", stats.Evaluations)
+	}
+}
+
+// TestPerGeneModeRequiresGeneMutator verifies validation rejects
+// individuals that cannot mutate single loci
+func TestPerGeneModeRequiresGeneMutator(t *testing.T) {
+	algorithm := New(
+		WithPopulation(mockPopulation(1, 2, 3)),
+		WithMutationMode(PerGene),
+	)
+	err := algorithm.Validate()
+	if err == nil || !strings.Contains(err.Error(), "GeneMutator") {
+		t.Errorf("Expected GeneMutator error, got %v", err)
+	}
+
+	engine := NewEngine([]chromosomeIndividual{{&MockChromosome{}}}, WithMutationMode(PerGene))
+	if err := engine.Validate(); err == nil {
+		t.Error("Expected engine validation error, got nil")
+	}
+
+	if err := New(WithPopulation(mockPopulation(1)), WithMutationMode(MutationMode(7))).Validate(); err == nil {
+		t.Error("Expected error for unknown mutation mode, got nil")
+	}
+}
+
+// TestTSPMutateGenePreservesCities verifies per-gene mutation keeps the
+// route a permutation
+func TestTSPMutateGenePreservesCities(t *testing.T) {
+	c := newTSPPopulation(1, 8, 1)[0].(*TSPChromosome)
+	before := routeNames(c)
+	rng := rand.New(rand.NewSource(1))
+	for i := 0; i < c.Len(); i++ {
+		c.MutateGene(i, rng)
+	}
+
+	after := routeNames(c)
+	if after == before {
+		t.Error("Expected the route to change")
+	}
+	for _, city := range before {
+		if strings.Count(after, string(city)) != 1 {
+			t.Fatalf("City %c missing or duplicated in %s", city, after)
+		}
+	}
+}
@@ -0,0 +1,391 @@
+package ga
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+)
+
+// Distance measures how far apart two chromosomes are in genotype or
+// phenotype space. It must be non-negative and symmetric, and return 0 for
+// identical genomes. Niching methods use it to decide which individuals
+// compete with each other; when it is nil they use the individuals'
+// Distancer implementation instead, Distancer[T] for an Engine[T], and
+// Validate reports an error if there is none.
+//
+// During Run the arguments are the user's chromosomes. Engine[T] hands out
+// its population wrappers instead when T is not a Chromosome; use
+// IndividualOf to recover the typed values.
+type Distance func(a, b Chromosome) float64
+
+// distanceBetween applies distance to the user's chromosomes behind a and b,
+// falling back to Distancer if distance is nil: Distancer[T] for the members
+// of an Engine[T], Distancer[Chromosome] otherwise.
+func distanceBetween(distance Distance, a, b Chromosome) float64 {
+	if distance == nil {
+		if m, ok := a.(interface {
+			distanceTo(other Chromosome) (float64, bool)
+		}); ok {
+			if d, ok := m.distanceTo(b); ok {
+				return d
+			}
+		}
+	}
+	a, b = chromosomeView(a), chromosomeView(b)
+	if distance != nil {
+		return distance(a, b)
+	}
+	d, ok := a.(Distancer[Chromosome])
+	if !ok {
+		panic(fmt.Sprintf("ga: no Distance function set and %T does not implement Distancer", a))
+	}
+	return d.Distance(b)
+}
+
+// distanceTo returns the Distancer[T] distance between m and other if other
+// is a member of the same kind and T implements Distancer[T].
+func (m *member[T]) distanceTo(other Chromosome) (float64, bool) {
+	o, ok := other.(*member[T])
+	if !ok {
+		return 0, false
+	}
+	d, ok := any(m.value).(Distancer[T])
+	if !ok {
+		return 0, false
+	}
+	return d.Distance(o.value), true
+}
+
+// nichingDistance returns the Distance of the configured niching method and
+// whether there is one.
+func (ga *GA) nichingDistance() (Distance, bool) {
+	if f, ok := ga.selector.(*FitnessSharing); ok {
+		return f.Distance, true
+	}
+	switch r := ga.replacement.(type) {
+	case DeterministicCrowding:
+		return r.Distance, true
+	case *DeterministicCrowding:
+		return r.Distance, true
+	case ProbabilisticCrowding:
+		return r.Distance, true
+	case *ProbabilisticCrowding:
+		return r.Distance, true
+	}
+	return nil, false
+}
+
+// validateDistancer checks that individual i can be compared by the
+// configured niching method when it has no Distance function. typed reports
+// whether the individual implements Distancer[T] for its Engine.
+func (ga *GA) validateDistancer(i int, individual any, typed bool) error {
+	distance, ok := ga.nichingDistance()
+	if !ok || distance != nil || typed {
+		return nil
+	}
+	if _, ok := individual.(Distancer[Chromosome]); !ok {
+		return fmt.Errorf("niching without a Distance function requires Distancer, individual at index %d is %T", i, individual)
+	}
+	return nil
+}
+
+// chromosomeView returns the user's chromosome behind a population member,
+// or c itself if there is none.
+func chromosomeView(c Chromosome) Chromosome {
+	if m, ok := c.(interface{ individual() any }); ok {
+		if chromosome, ok := m.individual().(Chromosome); ok {
+			return chromosome
+		}
+	}
+	return c
+}
+
+// FitnessSharing wraps a Selector so that it selects on shared fitness,
+// which keeps the population spread over several optima of a multimodal
+// landscape. Each individual's fitness is divided by its niche count,
+//
+//	m(i) = Σ_j sh(d(i, j)),  sh(d) = 1 - (d/Sigma)^Alpha for d < Sigma, else 0
+//
+// so individuals in crowded regions look less fit and isolated peaks keep a
+// share of the offspring. Raw fitness is unchanged: elitism, statistics and
+// Best still see the true score.
+//
+// Fitness is shifted so the lowest finite value is zero before sharing if
+// any is negative. Sharing costs O(N²) Distance calls every time the
+// population changes, which in steady-state mode is after every brood.
+//
+// FitnessSharing keeps per-run state and must not be shared by GAs that run
+// concurrently.
+//
+// Example:
+//
+//	ga.WithSelector(&ga.FitnessSharing{
+//	    Selector: &ga.TournamentSelector{TournamentSize: 2},
+//	    Distance: func(a, b ga.Chromosome) float64 { ... },
+//	    Sigma:    0.1,
+//	})
+type FitnessSharing struct {
+	// Selector chooses parents using the shared fitness. A RouletteSelector
+	// is used if nil.
+	Selector Selector
+
+	// Distance measures how far apart two individuals are. Individuals
+	// must implement Distancer if nil.
+	Distance Distance
+
+	// Sigma is the niche radius: individuals closer than Sigma share
+	// fitness. With Sigma <= 0 no fitness is shared.
+	Sigma float64
+
+	// Alpha shapes the sharing function. Default is 1 (triangular) if not
+	// specified or if <= 0.
+	Alpha float64
+
+	roulette   RouletteSelector
+	population []Chromosome
+	shared     []Chromosome
+}
+
+// sharedChromosome presents a population member to the wrapped selector with
+// its shared fitness.
+type sharedChromosome struct {
+	Chromosome
+	index  int
+	shared float64
+}
+
+// Fitness returns the shared fitness.
+func (s *sharedChromosome) Fitness() float64 {
+	return s.shared
+}
+
+// Violation forwards the member's constraint violation, so the wrapped
+// selector still follows Deb's rules.
+func (s *sharedChromosome) Violation() float64 {
+	return violationOf(s.Chromosome)
+}
+
+// selector returns the wrapped selector.
+func (f *FitnessSharing) selector() Selector {
+	if f.Selector == nil {
+		return &f.roulette
+	}
+	return f.Selector
+}
+
+// SetGeneration forwards the generation to the wrapped selector if it is
+// GenerationAware.
+func (f *FitnessSharing) SetGeneration(generation, maxGenerations int) {
+	if g, ok := f.selector().(GenerationAware); ok {
+		g.SetGeneration(generation, maxGenerations)
+	}
+}
+
+// Prepare computes the shared fitness of population and prepares the
+// wrapped selector if it is PopulationAware.
+func (f *FitnessSharing) Prepare(population []Chromosome) {
+	minimum := 0.0
+	for _, c := range population {
+		if fitness := c.Fitness(); !math.IsInf(fitness, -1) && fitness < minimum {
+			minimum = fitness
+		}
+	}
+
+	alpha := f.Alpha
+	if alpha <= 0 {
+		alpha = 1
+	}
+	niche := make([]float64, len(population))
+	for i := range population {
+		niche[i] = 1 // sh(0) for the individual itself
+		if f.Sigma <= 0 {
+			continue
+		}
+		for j := 0; j < i; j++ {
+			d := distanceBetween(f.Distance, population[i], population[j])
+			if d < f.Sigma {
+				sh := 1 - math.Pow(d/f.Sigma, alpha)
+				niche[i] += sh
+				niche[j] += sh
+			}
+		}
+	}
+
+	f.population = population
+	f.shared = make([]Chromosome, len(population))
+	for i, c := range population {
+		f.shared[i] = &sharedChromosome{
+			Chromosome: c,
+			index:      i,
+			shared:     (c.Fitness() - minimum) / niche[i],
+		}
+	}
+	if p, ok := f.selector().(PopulationAware); ok {
+		p.Prepare(f.shared)
+	}
+}
+
+// Select runs the wrapped selector on the shared fitness and returns the
+// chosen individuals of population.
+//
+// THREAD SAFETY: Uses the provided rng parameter instead of global math/rand.
+func (f *FitnessSharing) Select(population []Chromosome, rng *rand.Rand) []Chromosome {
+	if len(population) == 0 {
+		return []Chromosome{}
+	}
+	if len(f.population) != len(population) || &f.population[0] != &population[0] {
+		f.Prepare(population)
+	}
+
+	parents := f.selector().Select(f.shared, rng)
+	for i, p := range parents {
+		if s, ok := p.(*sharedChromosome); ok {
+			parents[i] = population[s.index]
+		}
+	}
+	return parents
+}
+
+// DeterministicCrowding is a ReplacementStrategy for niching: each offspring
+// competes only with the more similar of its parents and replaces it if it
+// is at least as fit. Because offspring displace their own kind, separate
+// optima are not overwritten by one dominant region. If neither parent is
+// still in the population, the offspring competes with the most similar
+// individual instead.
+//
+// Use it with WithSteadyState and two offspring per step, the classic
+// setting in which both children of a pair compete with their parents.
+//
+// Example:
+//
+//	ga.WithSteadyState(ga.DeterministicCrowding{Distance: distance}, 2)
+type DeterministicCrowding struct {
+	// Distance measures how far apart two individuals are. Individuals
+	// must implement Distancer if nil.
+	Distance Distance
+}
+
+// Replace returns the index of the closer parent if offspring is at least as
+// fit, or -1.
+func (r DeterministicCrowding) Replace(population []Chromosome, offspring Chromosome, parents []int, rng *rand.Rand) int {
+	rival := closestRival(r.Distance, population, offspring, parents)
+	if Fitter(population[rival], offspring) {
+		return -1
+	}
+	return rival
+}
+
+// ProbabilisticCrowding is like DeterministicCrowding, but the offspring
+// beats the more similar parent with probability f(o) / (f(o) + f(p)) rather
+// than always when it is fitter, which lets weaker niches survive longer.
+// Negative and NaN fitness count as zero, so fitness should be non-negative;
+// individuals with +Inf fitness always win, and Deb's rules decide between a
+// feasible and an infeasible individual.
+type ProbabilisticCrowding struct {
+	// Distance measures how far apart two individuals are. Individuals
+	// must implement Distancer if nil.
+	Distance Distance
+}
+
+// Replace returns the index of the closer parent if offspring wins the
+// probabilistic contest, or -1.
+func (r ProbabilisticCrowding) Replace(population []Chromosome, offspring Chromosome, parents []int, rng *rand.Rand) int {
+	rival := closestRival(r.Distance, population, offspring, parents)
+	if violationOf(offspring) != violationOf(population[rival]) {
+		if Fitter(offspring, population[rival]) {
+			return rival
+		}
+		return -1
+	}
+	if rng.Float64() < winProbability(offspring.Fitness(), population[rival].Fitness()) {
+		return rival
+	}
+	return -1
+}
+
+// closestRival returns the parent most similar to offspring, or the most
+// similar individual in population if no parent is left.
+func closestRival(distance Distance, population []Chromosome, offspring Chromosome, parents []int) int {
+	candidates := parents
+	if len(candidates) == 0 {
+		candidates = make([]int, len(population))
+		for i := range candidates {
+			candidates[i] = i
+		}
+	}
+
+	closest, nearest := candidates[0], math.Inf(1)
+	for _, i := range candidates {
+		if d := distanceBetween(distance, offspring, population[i]); d < nearest {
+			closest, nearest = i, d
+		}
+	}
+	return closest
+}
+
+// winProbability returns the probability that an individual with fitness a
+// beats one with fitness b in probabilistic crowding.
+func winProbability(a, b float64) float64 {
+	switch {
+	case math.IsInf(a, 1) && math.IsInf(b, 1):
+		return 0.5
+	case math.IsInf(a, 1):
+		return 1
+	case math.IsInf(b, 1):
+		return 0
+	}
+	if !(a > 0) {
+		a = 0
+	}
+	if !(b > 0) {
+		b = 0
+	}
+	if a+b == 0 {
+		return 0.5
+	}
+	return a / (a + b)
+}
+
+// NichePeaks returns the best individual of each niche in population, best
+// first: the fittest individual, then the fittest one at least radius away
+// from it, and so on, each at least radius away from all peaks chosen
+// before it. Call it on GA.Population after a run with fitness sharing or
+// crowding to retrieve several distinct solutions.
+//
+// If distance is nil, the chromosomes must implement Distancer; an error
+// is returned if one does not or is nil.
+//
+// Example:
+//
+//	peaks, err := ga.NichePeaks(algorithm.Population, distance, 0.1)
+func NichePeaks(population []Chromosome, distance Distance, radius float64) ([]Chromosome, error) {
+	for i, c := range population {
+		if c == nil {
+			return nil, fmt.Errorf("population contains nil chromosome at index %d", i)
+		}
+		if _, ok := chromosomeView(c).(Distancer[Chromosome]); distance == nil && !ok {
+			return nil, fmt.Errorf("no Distance function set and chromosome at index %d (%T) does not implement Distancer", i, c)
+		}
+	}
+
+	sorted := append([]Chromosome(nil), population...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return Fitter(sorted[i], sorted[j])
+	})
+
+	var peaks []Chromosome
+	for _, c := range sorted {
+		distinct := true
+		for _, peak := range peaks {
+			if distanceBetween(distance, c, peak) < radius {
+				distinct = false
+				break
+			}
+		}
+		if distinct {
+			peaks = append(peaks, c)
+		}
+	}
+	return peaks, nil
+}
@@ -0,0 +1,295 @@
+package ga
+
+import (
+	"math"
+	"math/rand"
+	"strings"
+	"testing"
+)
+
+// peakChromosome maximizes sin⁶(5πx) on [0, 1], which has five equal peaks
+// at x = 0.1, 0.3, 0.5, 0.7 and 0.9.
+type peakChromosome struct {
+	x float64
+}
+
+func (c *peakChromosome) Fitness() float64 {
+	return math.Pow(math.Sin(5*math.Pi*c.x), 6)
+}
+
+func (c *peakChromosome) Crossover(other Chromosome) Chromosome {
+	return &peakChromosome{x: (c.x + other.(*peakChromosome).x) / 2}
+}
+
+func (c *peakChromosome) CrossoverRand(other Chromosome, rng *rand.Rand) Chromosome {
+	w := rng.Float64()
+	return &peakChromosome{x: w*c.x + (1-w)*other.(*peakChromosome).x}
+}
+
+func (c *peakChromosome) Mutate() {}
+
+func (c *peakChromosome) MutateRand(rng *rand.Rand) {
+	c.x = math.Min(1, math.Max(0, c.x+rng.NormFloat64()*0.02))
+}
+
+func (c *peakChromosome) Clone() Chromosome { return &peakChromosome{x: c.x} }
+
+func peakDistance(a, b Chromosome) float64 {
+	return math.Abs(a.(*peakChromosome).x - b.(*peakChromosome).x)
+}
+
+func newPeakPopulation(size int, seed int64) []Chromosome {
+	rng := rand.New(rand.NewSource(seed))
+	population := make([]Chromosome, size)
+	for i := range population {
+		population[i] = &peakChromosome{x: rng.Float64()}
+	}
+	return population
+}
+
+// foundPeaks counts the optima of peakChromosome with a niche peak of
+// fitness above 0.9 near them.
+func foundPeaks(population []Chromosome) int {
+	found := 0
+	peaks, err := NichePeaks(population, peakDistance, 0.1)
+	if err != nil {
+		return 0
+	}
+	for _, optimum := range []float64{0.1, 0.3, 0.5, 0.7, 0.9} {
+		for _, p := range peaks {
+			if math.Abs(p.(*peakChromosome).x-optimum) < 0.05 && p.Fitness() > 0.9 {
+				found++
+				break
+			}
+		}
+	}
+	return found
+}
+
+// TestFitnessSharingDividesByNicheCount verifies shared fitness and that
+// parents are mapped back to the population
+func TestFitnessSharingDividesByNicheCount(t *testing.T) {
+	population := []Chromosome{&peakChromosome{x: 0.1}, &peakChromosome{x: 0.1}, &peakChromosome{x: 0.9}}
+	sharing := &FitnessSharing{Distance: peakDistance, Sigma: 0.5}
+	sharing.Prepare(population)
+
+	for i, want := range []float64{0.5, 0.5, 1} {
+		if got := sharing.shared[i].Fitness(); math.Abs(got-want*population[i].Fitness()) > 1e-9 {
+			t.Errorf("Individual %d: expected shared fitness %.3f, got %.3f", i, want*population[i].Fitness(), got)
+		}
+	}
+
+	rng := rand.New(rand.NewSource(1))
+	for i := 0; i < 20; i++ {
+		for _, p := range sharing.Select(population, rng) {
+			if _, ok := p.(*peakChromosome); !ok {
+				t.Fatalf("Expected a population member, got %T", p)
+			}
+		}
+	}
+}
+
+// TestFitnessSharingFindsSeveralPeaks verifies sharing keeps the population
+// on several optima where the same run without sharing collapses
+func TestFitnessSharingFindsSeveralPeaks(t *testing.T) {
+	run := func(selector Selector) int {
+		algorithm := New(
+			WithPopulation(newPeakPopulation(100, 1)),
+			WithGenerations(60),
+			WithMutationRate(0.3),
+			WithSelector(selector),
+			WithRandomSeed(1),
+		)
+		if err := algorithm.Run(); err != nil {
+			t.Fatalf("Run failed: %v", err)
+		}
+		return foundPeaks(algorithm.Population)
+	}
+
+	shared := run(&FitnessSharing{Distance: peakDistance, Sigma: 0.1})
+	if shared < 4 {
+		t.Errorf("Expected at least 4 of 5 peaks, found %d", shared)
+	}
+	if plain := run(&RouletteSelector{}); plain >= shared {
+		t.Errorf("Expected sharing to find more peaks than plain roulette, found %d and %d", shared, plain)
+	}
+}
+
+// TestCrowdingFindsSeveralPeaks verifies both crowding strategies keep the
+// population on several optima
+func TestCrowdingFindsSeveralPeaks(t *testing.T) {
+	strategies := map[string]ReplacementStrategy{
+		"deterministic": DeterministicCrowding{Distance: peakDistance},
+		"probabilistic": ProbabilisticCrowding{Distance: peakDistance},
+	}
+	for name, strategy := range strategies {
+		t.Run(name, func(t *testing.T) {
+			algorithm := New(
+				WithPopulation(newPeakPopulation(100, 1)),
+				WithGenerations(60),
+				WithMutationRate(0.3),
+				WithCrossoverRate(0.2),
+				WithSelector(&TruncationSelector{Fraction: 1}),
+				WithSteadyState(strategy, 2),
+				WithRandomSeed(1),
+			)
+			if err := algorithm.Run(); err != nil {
+				t.Fatalf("Run failed: %v", err)
+			}
+			if found := foundPeaks(algorithm.Population); found < 4 {
+				t.Errorf("Expected at least 4 of 5 peaks, found %d", found)
+			}
+		})
+	}
+}
+
+// TestDeterministicCrowdingReplacesCloserParent verifies the offspring only
+// competes with the more similar parent
+func TestDeterministicCrowdingReplacesCloserParent(t *testing.T) {
+	population := []Chromosome{&peakChromosome{x: 0.1}, &peakChromosome{x: 0.28}, &peakChromosome{x: 0.5}}
+	crowding := DeterministicCrowding{Distance: peakDistance}
+
+	if got := crowding.Replace(population, &peakChromosome{x: 0.3}, []int{0, 1}, nil); got != 1 {
+		t.Errorf("Expected the closer parent 1 to be replaced, got %d", got)
+	}
+	if got := crowding.Replace(population, &peakChromosome{x: 0.2}, []int{0, 2}, nil); got != -1 {
+		t.Errorf("Expected the worse offspring to be discarded, got %d", got)
+	}
+	if got := crowding.Replace(population, &peakChromosome{x: 0.45}, nil, nil); got != -1 {
+		t.Errorf("Expected the offspring to lose to the closest individual, got %d", got)
+	}
+}
+
+// TestWinProbability verifies the probabilistic crowding contest
+func TestWinProbability(t *testing.T) {
+	cases := []struct {
+		a, b, want float64
+	}{
+		{3, 1, 0.75},
+		{0, 0, 0.5},
+		{-1, 2, 0},
+		{math.NaN(), 1, 0},
+		{math.Inf(1), 5, 1},
+		{5, math.Inf(1), 0},
+		{math.Inf(1), math.Inf(1), 0.5},
+	}
+	for _, c := range cases {
+		if got := winProbability(c.a, c.b); got != c.want {
+			t.Errorf("winProbability(%v, %v) = %v, want %v", c.a, c.b, got, c.want)
+		}
+	}
+}
+
+// TestNichePeaks verifies peaks are the fittest individuals at least radius
+// apart
+func TestNichePeaks(t *testing.T) {
+	population := []Chromosome{
+		&peakChromosome{x: 0.12}, &peakChromosome{x: 0.1}, &peakChromosome{x: 0.5}, &peakChromosome{x: 0.55},
+	}
+	peaks, err := NichePeaks(population, peakDistance, 0.1)
+	if err != nil {
+		t.Fatalf("NichePeaks failed: %v", err)
+	}
+	if len(peaks) != 2 || peaks[0] != population[1] && peaks[0] != population[2] {
+		t.Fatalf("Expected the peaks at 0.1 and 0.5, got %d peaks", len(peaks))
+	}
+	for _, p := range peaks {
+		if x := p.(*peakChromosome).x; x != 0.1 && x != 0.5 {
+			t.Errorf("Unexpected peak at %.2f", x)
+		}
+	}
+
+	if _, err := NichePeaks(mockPopulation(1, 2), nil, 0.1); err == nil || !strings.Contains(err.Error(), "Distancer") {
+		t.Errorf("Expected an error without a Distance or Distancer, got %v", err)
+	}
+	if _, err := NichePeaks([]Chromosome{population[0], nil}, peakDistance, 0.1); err == nil {
+		t.Error("Expected an error for a nil chromosome")
+	}
+}
+
+// typedPeak is peakChromosome as a typed Engine individual with its own
+// Distancer.
+type typedPeak struct {
+	x float64
+}
+
+func (c *typedPeak) Fitness() float64 {
+	return math.Pow(math.Sin(5*math.Pi*c.x), 6)
+}
+
+func (c *typedPeak) Crossover(other *typedPeak) *typedPeak {
+	return &typedPeak{x: (c.x + other.x) / 2}
+}
+
+func (c *typedPeak) Mutate() {}
+
+func (c *typedPeak) MutateRand(rng *rand.Rand) {
+	c.x = math.Min(1, math.Max(0, c.x+rng.NormFloat64()*0.02))
+}
+
+func (c *typedPeak) Clone() *typedPeak { return &typedPeak{x: c.x} }
+
+func (c *typedPeak) Distance(other *typedPeak) float64 { return math.Abs(c.x - other.x) }
+
+// TestNichingWithTypedDistancer verifies niching on an Engine falls back to
+// the individuals' Distancer[T]
+func TestNichingWithTypedDistancer(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	population := make([]*typedPeak, 50)
+	for i := range population {
+		population[i] = &typedPeak{x: rng.Float64()}
+	}
+
+	a, b := &member[*typedPeak]{value: population[0]}, &member[*typedPeak]{value: population[1]}
+	if d := distanceBetween(nil, a, b); d != population[0].Distance(population[1]) {
+		t.Errorf("Expected the Distancer[T] distance, got %f", d)
+	}
+
+	configs := map[string][]func(*GA){
+		"sharing": {WithSelector(&FitnessSharing{Selector: &TournamentSelector{TournamentSize: 2}, Sigma: 0.1})},
+		"deterministic": {
+			WithSelector(&TruncationSelector{Fraction: 1}),
+			WithSteadyState(DeterministicCrowding{}, 2),
+		},
+		"probabilistic": {
+			WithSelector(&TruncationSelector{Fraction: 1}),
+			WithSteadyState(&ProbabilisticCrowding{}, 2),
+		},
+	}
+	for name, opts := range configs {
+		t.Run(name, func(t *testing.T) {
+			values := make([]*typedPeak, len(population))
+			for i, c := range population {
+				values[i] = c.Clone()
+			}
+			opts := append([]func(*GA){WithGenerations(10), WithMutationRate(0.3), WithRandomSeed(1)}, opts...)
+			if err := NewEngine(values, opts...).Run(); err != nil {
+				t.Fatalf("Run failed: %v", err)
+			}
+		})
+	}
+}
+
+// TestNichingRequiresDistance verifies a niching method without a Distance
+// function is rejected when individuals do not implement Distancer
+func TestNichingRequiresDistance(t *testing.T) {
+	configs := map[string]func(*GA){
+		"sharing":       WithSelector(&FitnessSharing{Sigma: 0.1}),
+		"deterministic": WithSteadyState(&DeterministicCrowding{}, 2),
+		"probabilistic": WithSteadyState(ProbabilisticCrowding{}, 2),
+	}
+	for name, opt := range configs {
+		err := New(WithPopulation(mockPopulation(1, 2, 3)), opt).Validate()
+		if err == nil || !strings.Contains(err.Error(), "requires Distancer") {
+			t.Errorf("%s: expected a missing Distancer error, got %v", name, err)
+		}
+		if err := NewEngine(newBitStrings(4, 8, 1), opt).Validate(); err == nil {
+			t.Errorf("%s: expected a missing Distancer error for Engine", name)
+		}
+	}
+
+	withDistance := WithSteadyState(DeterministicCrowding{Distance: peakDistance}, 2)
+	if err := New(WithPopulation(mockPopulation(1, 2, 3)), withDistance).Validate(); err != nil {
+		t.Errorf("Expected a Distance function to be enough, got %v", err)
+	}
+}
@@ -0,0 +1,440 @@
+package ga
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+)
+
+// MultiObjectiveChromosome is a chromosome scored on several objectives at
+// once, such as cost, latency and accuracy. Every objective is maximized,
+// like Fitness; negate objectives that should be minimized.
+//
+// NSGA2 uses Objectives only. Fitness is still required by Chromosome, so the
+// same type can also run in a single-objective GA (for example with a
+// weighted sum of the objectives).
+type MultiObjectiveChromosome interface {
+	Chromosome
+
+	// Objectives returns the objective values. Every chromosome in a
+	// population must return the same number of objectives, in the same
+	// order.
+	Objectives() []float64
+}
+
+// NSGA2 runs the NSGA-II multi-objective evolutionary algorithm (Deb et al.,
+// 2002). Instead of a single best chromosome it evolves an approximation of
+// the Pareto front: the set of solutions that no other solution beats on
+// every objective.
+//
+// Each generation breeds a full set of offspring with binary tournaments
+// on Pareto rank and crowding distance, merges them with the parents, and
+// keeps the best half by non-dominated sorting, breaking ties in the last
+// admitted front by crowding distance to keep the front well spread.
+//
+// NSGA2 is configured with the same option functions as GA. It honours
+// WithGenerations, WithMutationRate, WithCrossoverRate, WithRandomSeed and
+// WithParallelEvaluation; options that depend on a single fitness, such as
+// selectors, elitism and convergence, are ignored, and WithPopulation is
+// ignored because the population is passed to NewNSGA2 directly. Validate
+// rejects WithCheckpointer, WithTerminator, WithObserver,
+// WithMutationSchedule, WithCrossoverSchedule, WithInitializer and
+// WithHallOfFame, whose results a run would otherwise silently drop.
+type NSGA2 struct {
+	// Population is the current population. It is updated after every
+	// generation and holds the final survivors once the run ends.
+	Population []MultiObjectiveChromosome
+
+	config *GA
+	front  []MultiObjectiveChromosome
+}
+
+// NewNSGA2 creates an NSGA-II run over population, configured with the same
+// options and defaults as New.
+//
+// Example:
+//
+//	nsga := ga.NewNSGA2(designs,
+//	    ga.WithGenerations(200),
+//	    ga.WithMutationRate(0.1),
+//	    ga.WithRandomSeed(42),
+//	)
+//	if err := nsga.Run(); err != nil {
+//	    log.Fatal(err)
+//	}
+//	for _, design := range nsga.ParetoFront() {
+//	    fmt.Println(design.Objectives())
+//	}
+func NewNSGA2(population []MultiObjectiveChromosome, options ...func(*GA)) *NSGA2 {
+	return &NSGA2{
+		Population: population,
+		config:     New(options...),
+	}
+}
+
+// Validate checks if the configuration is valid and returns an error if any
+// issues are found. It applies the same checks as GA.Validate.
+func (n *NSGA2) Validate() error {
+	if option := n.unsupportedOption(); option != "" {
+		return fmt.Errorf("%s is not supported by NSGA2", option)
+	}
+	if err := validatePopulationSize(len(n.Population)); err != nil {
+		return err
+	}
+	if err := n.config.validateSettings(); err != nil {
+		return err
+	}
+	for i, c := range n.Population {
+		if c == nil {
+			return fmt.Errorf("population contains nil chromosome at index %d", i)
+		}
+		if err := n.config.validateMutationMode(i, c); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// unsupportedOption returns the name of the first configured option that
+// NSGA2 cannot honour, or "" if there is none.
+func (n *NSGA2) unsupportedOption() string {
+	cfg := n.config
+	switch {
+	case cfg.checkpointer != nil:
+		return "WithCheckpointer"
+	case cfg.terminator != nil:
+		return "WithTerminator"
+	case len(cfg.observers) > 0:
+		return "WithObserver"
+	case cfg.mutationSchedule != nil:
+		return "WithMutationSchedule"
+	case cfg.crossoverSchedule != nil:
+		return "WithCrossoverSchedule"
+	case cfg.initializer != nil:
+		return "WithInitializer"
+	case cfg.hallOfFameSize > 0:
+		return "WithHallOfFame"
+	}
+	return ""
+}
+
+// Run evolves the population for the configured number of generations.
+func (n *NSGA2) Run() error {
+	_, err := n.RunContext(context.Background())
+	return err
+}
+
+// RunContext is like Run but stops early when ctx is cancelled or its
+// deadline expires. On cancellation ParetoFront returns the front of the last
+// completed generation and the error wraps ctx.Err().
+func (n *NSGA2) RunContext(ctx context.Context) (Stats, error) {
+	if err := n.Validate(); err != nil {
+		return Stats{}, fmt.Errorf("invalid NSGA-II configuration: %w", err)
+	}
+
+	cfg := n.config
+	start := time.Now()
+	var stats Stats
+	stop := func(reason StopReason, err error) (Stats, error) {
+		stats.StopReason = reason
+		stats.Duration = time.Since(start)
+		return stats, err
+	}
+	cancelled := func(generation int) (Stats, error) {
+		return stop(StopCancelled, fmt.Errorf("run cancelled at generation %d: %w", generation, ctx.Err()))
+	}
+
+	population := make([]*moMember, len(n.Population))
+	for i, c := range n.Population {
+		population[i] = &moMember{chromosome: c}
+	}
+	evaluations, err := n.evaluate(population, 0)
+	stats.Evaluations += evaluations
+	if err != nil {
+		return stop(StopNone, err)
+	}
+	population = survivors(population, len(population))
+	n.setPopulation(population)
+
+	for generation := 0; generation < cfg.Generations; generation++ {
+		if ctx.Err() != nil {
+			return cancelled(generation)
+		}
+
+		offspring := make([]*moMember, len(population))
+		for i := range offspring {
+			if i%cancelCheckInterval == 0 && ctx.Err() != nil {
+				return cancelled(generation)
+			}
+			child, err := n.breed(population)
+			if err != nil {
+				return stop(StopNone, err)
+			}
+			offspring[i] = child
+		}
+
+		evaluations, err := n.evaluate(offspring, len(population[0].objectives))
+		stats.Evaluations += evaluations
+		if err != nil {
+			return stop(StopNone, err)
+		}
+
+		// Parents and offspring compete for the next generation
+		combined := make([]*moMember, 0, 2*len(population))
+		combined = append(combined, population...)
+		combined = append(combined, offspring...)
+		population = survivors(combined, len(population))
+		n.setPopulation(population)
+		stats.Generations = generation + 1
+	}
+
+	return stop(StopMaxGenerations, nil)
+}
+
+// ParetoFront returns the non-dominated chromosomes of the current
+// population, or nil if Run has not been called yet.
+func (n *NSGA2) ParetoFront() []MultiObjectiveChromosome {
+	return n.front
+}
+
+// moMember is a population member with cached objectives and the rank and
+// crowding distance assigned by the last non-dominated sort.
+type moMember struct {
+	chromosome MultiObjectiveChromosome
+	objectives []float64
+	rank       int
+	crowding   float64
+}
+
+// evaluate computes the objectives of members that have none cached and
+// returns the number of Objectives calls. Every member must have want
+// objectives, or as many as the first member if want is 0.
+func (n *NSGA2) evaluate(population []*moMember, want int) (int, error) {
+	pending := make([]*moMember, 0, len(population))
+	for _, m := range population {
+		if m.objectives == nil {
+			pending = append(pending, m)
+		}
+	}
+
+	forEach(len(pending), n.config.evaluationWorkers, func(i int) {
+		objectives := append([]float64{}, pending[i].chromosome.Objectives()...)
+		for j, v := range objectives {
+			// NaN never dominates anything
+			if math.IsNaN(v) {
+				objectives[j] = math.Inf(-1)
+			}
+		}
+		pending[i].objectives = objectives
+	})
+
+	if want == 0 {
+		want = len(population[0].objectives)
+	}
+	if want == 0 {
+		return len(pending), fmt.Errorf("chromosomes must return at least one objective")
+	}
+	for _, m := range population {
+		if len(m.objectives) != want {
+			return len(pending), fmt.Errorf("chromosome %T returned %d objectives, expected %d",
+				m.chromosome, len(m.objectives), want)
+		}
+	}
+	return len(pending), nil
+}
+
+// breed produces one offspring from two crowded-tournament winners. A clone
+// that is not mutated inherits its parent's objectives.
+func (n *NSGA2) breed(population []*moMember) (*moMember, error) {
+	cfg := n.config
+	parent1 := crowdedTournament(population, cfg)
+	parent2 := crowdedTournament(population, cfg)
+
+	var child chromosomeIndividual
+	var objectives []float64
+	if cfg.rng.Float64() < cfg.CrossoverRate {
+		child = chromosomeIndividual{parent1.chromosome}.CrossoverRand(chromosomeIndividual{parent2.chromosome}, cfg.rng)
+	} else {
+		child = chromosomeIndividual{parent1.chromosome.Clone()}
+		objectives = parent1.objectives
+	}
+	if cfg.mutationMode == PerGene {
+		if mutateGenes(child.Chromosome.(GeneMutator), cfg.MutationRate, cfg.rng) {
+			objectives = nil
+		}
+	} else if cfg.rng.Float64() < cfg.MutationRate {
+		child.MutateRand(cfg.rng)
+		objectives = nil
+	}
+
+	mo, ok := child.Chromosome.(MultiObjectiveChromosome)
+	if !ok {
+		return nil, fmt.Errorf("offspring %T does not implement MultiObjectiveChromosome", child.Chromosome)
+	}
+	return &moMember{chromosome: mo, objectives: objectives}, nil
+}
+
+// crowdedTournament returns the better of two random members: the lower
+// Pareto rank wins, and on equal rank the larger crowding distance.
+func crowdedTournament(population []*moMember, cfg *GA) *moMember {
+	a := population[cfg.rng.Intn(len(population))]
+	b := population[cfg.rng.Intn(len(population))]
+	if b.rank < a.rank || (b.rank == a.rank && b.crowding > a.crowding) {
+		return b
+	}
+	return a
+}
+
+// setPopulation publishes the survivors and the current Pareto front.
+func (n *NSGA2) setPopulation(population []*moMember) {
+	n.Population = make([]MultiObjectiveChromosome, len(population))
+	n.front = nil
+	for i, m := range population {
+		n.Population[i] = m.chromosome
+		if m.rank == 0 {
+			n.front = append(n.front, m.chromosome)
+		}
+	}
+}
+
+// survivors ranks candidates by non-dominated sorting and returns the best
+// size of them, filling the last admitted front by descending crowding
+// distance. Rank and crowding are updated on every returned member.
+func survivors(candidates []*moMember, size int) []*moMember {
+	objectives := make([][]float64, len(candidates))
+	for i, m := range candidates {
+		objectives[i] = m.objectives
+	}
+
+	next := make([]*moMember, 0, size)
+	for rank, front := range NonDominatedSort(objectives) {
+		frontObjectives := make([][]float64, len(front))
+		for i, idx := range front {
+			frontObjectives[i] = objectives[idx]
+		}
+		distances := CrowdingDistance(frontObjectives)
+
+		members := make([]*moMember, len(front))
+		for i, idx := range front {
+			members[i] = candidates[idx]
+			members[i].rank = rank
+			members[i].crowding = distances[i]
+		}
+
+		if len(next)+len(members) > size {
+			sort.SliceStable(members, func(a, b int) bool {
+				return members[a].crowding > members[b].crowding
+			})
+			members = members[:size-len(next)]
+		}
+		next = append(next, members...)
+		if len(next) == size {
+			break
+		}
+	}
+	return next
+}
+
+// Dominates reports whether objective vector a Pareto-dominates b: a is at
+// least as good on every objective and strictly better on at least one.
+// Objectives are maximized.
+func Dominates(a, b []float64) bool {
+	better := false
+	for i := range a {
+		switch {
+		case a[i] < b[i]:
+			return false
+		case a[i] > b[i]:
+			better = true
+		}
+	}
+	return better
+}
+
+// NonDominatedSort partitions objective vectors into Pareto fronts using
+// the fast non-dominated sort of NSGA-II. The first front holds the indices
+// of the vectors no other vector dominates, the second those dominated only
+// by the first front, and so on. Objectives are maximized.
+func NonDominatedSort(objectives [][]float64) [][]int {
+	n := len(objectives)
+	dominatedBy := make([]int, n)  // number of vectors dominating i
+	dominating := make([][]int, n) // vectors that i dominates
+
+	var front []int
+	for i := 0; i < n; i++ {
+		for j := i + 1; j < n; j++ {
+			switch {
+			case Dominates(objectives[i], objectives[j]):
+				dominating[i] = append(dominating[i], j)
+				dominatedBy[j]++
+			case Dominates(objectives[j], objectives[i]):
+				dominating[j] = append(dominating[j], i)
+				dominatedBy[i]++
+			}
+		}
+	}
+	for i := 0; i < n; i++ {
+		if dominatedBy[i] == 0 {
+			front = append(front, i)
+		}
+	}
+
+	var fronts [][]int
+	for len(front) > 0 {
+		fronts = append(fronts, front)
+		var next []int
+		for _, i := range front {
+			for _, j := range dominating[i] {
+				dominatedBy[j]--
+				if dominatedBy[j] == 0 {
+					next = append(next, j)
+				}
+			}
+		}
+		sort.Ints(next)
+		front = next
+	}
+	return fronts
+}
+
+// CrowdingDistance returns the crowding distance of each vector within a
+// single front: the sum over objectives of the normalized gap between its
+// neighbours. Boundary vectors get +Inf so the extremes of the front are
+// always kept.
+func CrowdingDistance(objectives [][]float64) []float64 {
+	n := len(objectives)
+	distances := make([]float64, n)
+	if n == 0 {
+		return distances
+	}
+	if n <= 2 {
+		for i := range distances {
+			distances[i] = math.Inf(1)
+		}
+		return distances
+	}
+
+	order := make([]int, n)
+	for m := range objectives[0] {
+		for i := range order {
+			order[i] = i
+		}
+		sort.SliceStable(order, func(a, b int) bool {
+			return objectives[order[a]][m] < objectives[order[b]][m]
+		})
+
+		low, high := objectives[order[0]][m], objectives[order[n-1]][m]
+		distances[order[0]] = math.Inf(1)
+		distances[order[n-1]] = math.Inf(1)
+		span := high - low
+		if span == 0 || math.IsInf(span, 0) || math.IsNaN(span) {
+			continue
+		}
+		for k := 1; k < n-1; k++ {
+			distances[order[k]] += (objectives[order[k+1]][m] - objectives[order[k-1]][m]) / span
+		}
+	}
+	return distances
+}
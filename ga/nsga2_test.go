@@ -0,0 +1,200 @@
+package ga
+
+import (
+	"context"
+	"errors"
+	"math"
+	"math/rand"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// schafferChromosome is Schaffer's single-variable problem with objectives
+// -x² and -(x-2)², whose Pareto set is x in [0, 2].
+type schafferChromosome struct {
+	x float64
+}
+
+func (c *schafferChromosome) Objectives() []float64 {
+	return []float64{-c.x * c.x, -(c.x - 2) * (c.x - 2)}
+}
+
+func (c *schafferChromosome) Fitness() float64 {
+	objectives := c.Objectives()
+	return objectives[0] + objectives[1]
+}
+
+func (c *schafferChromosome) Crossover(other Chromosome) Chromosome {
+	return &schafferChromosome{x: (c.x + other.(*schafferChromosome).x) / 2}
+}
+
+func (c *schafferChromosome) CrossoverRand(other Chromosome, rng *rand.Rand) Chromosome {
+	w := rng.Float64()
+	return &schafferChromosome{x: w*c.x + (1-w)*other.(*schafferChromosome).x}
+}
+
+func (c *schafferChromosome) Mutate() {}
+
+func (c *schafferChromosome) MutateRand(rng *rand.Rand) {
+	c.x += rng.NormFloat64() * 0.5
+}
+
+func (c *schafferChromosome) Clone() Chromosome {
+	return &schafferChromosome{x: c.x}
+}
+
+func newSchafferPopulation(size int, seed int64) []MultiObjectiveChromosome {
+	rng := rand.New(rand.NewSource(seed))
+	population := make([]MultiObjectiveChromosome, size)
+	for i := range population {
+		population[i] = &schafferChromosome{x: rng.Float64()*20 - 10}
+	}
+	return population
+}
+
+// TestNSGA2FindsParetoFront verifies the final front lies on Schaffer's
+// Pareto set and spreads across it
+func TestNSGA2FindsParetoFront(t *testing.T) {
+	nsga := NewNSGA2(newSchafferPopulation(40, 1),
+		WithGenerations(60),
+		WithMutationRate(0.3),
+		WithRandomSeed(7),
+	)
+	stats, err := nsga.RunContext(context.Background())
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	if stats.Generations != 60 || stats.StopReason != StopMaxGenerations {
+		t.Errorf("Expected 60 generations and StopMaxGenerations, got %d and %v", stats.Generations, stats.StopReason)
+	}
+
+	front := nsga.ParetoFront()
+	if len(front) < 10 {
+		t.Fatalf("Expected a populated front, got %d members", len(front))
+	}
+	low, high := math.Inf(1), math.Inf(-1)
+	for _, c := range front {
+		x := c.(*schafferChromosome).x
+		if x < -0.05 || x > 2.05 {
+			t.Errorf("Front member x=%v is outside the Pareto set [0, 2]", x)
+		}
+		low, high = math.Min(low, x), math.Max(high, x)
+	}
+	if high-low < 1.5 {
+		t.Errorf("Expected the front to spread over the Pareto set, got [%v, %v]", low, high)
+	}
+
+	for i, a := range front {
+		for j, b := range front {
+			if i != j && Dominates(a.Objectives(), b.Objectives()) {
+				t.Fatalf("Front member %d dominates member %d", i, j)
+			}
+		}
+	}
+}
+
+// TestNSGA2ReproducibleWithSeed verifies seeded runs give the same front
+func TestNSGA2ReproducibleWithSeed(t *testing.T) {
+	run := func() []float64 {
+		nsga := NewNSGA2(newSchafferPopulation(20, 2),
+			WithGenerations(15),
+			WithMutationRate(0.3),
+			WithParallelEvaluation(4),
+			WithRandomSeed(3),
+		)
+		if err := nsga.Run(); err != nil {
+			t.Fatalf("Run failed: %v", err)
+		}
+		var xs []float64
+		for _, c := range nsga.ParetoFront() {
+			xs = append(xs, c.(*schafferChromosome).x)
+		}
+		return xs
+	}
+
+	if first, second := run(), run(); !reflect.DeepEqual(first, second) {
+		t.Errorf("Seeded NSGA-II runs differ:\n%v\n%v", first, second)
+	}
+}
+
+// TestNSGA2Cancellation verifies a cancelled run reports StopCancelled
+func TestNSGA2Cancellation(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	nsga := NewNSGA2(newSchafferPopulation(10, 1), WithGenerations(10))
+	stats, err := nsga.RunContext(ctx)
+	if !errors.Is(err, context.Canceled) || stats.StopReason != StopCancelled {
+		t.Errorf("Expected cancellation, got %v and %v", err, stats.StopReason)
+	}
+	if len(nsga.ParetoFront()) == 0 {
+		t.Error("Expected the initial front to be available after cancellation")
+	}
+}
+
+// TestNSGA2Validate verifies invalid configurations are rejected
+func TestNSGA2Validate(t *testing.T) {
+	if err := NewNSGA2(nil).Validate(); err == nil {
+		t.Error("Expected error for empty population")
+	}
+	if err := NewNSGA2([]MultiObjectiveChromosome{nil}).Validate(); err == nil {
+		t.Error("Expected error for nil chromosome")
+	}
+	if err := NewNSGA2(newSchafferPopulation(4, 1), WithGenerations(0)).Validate(); err == nil {
+		t.Error("Expected error for zero generations")
+	}
+
+	unsupported := map[string]func(*GA){
+		"WithCheckpointer":      WithCheckpointer(&memoryCheckpointer{}, 1),
+		"WithTerminator":        WithTerminator(Plateau{Generations: 5}),
+		"WithObserver":          WithObserver(ObserverFunc(func(Event) {})),
+		"WithMutationSchedule":  WithMutationSchedule(LinearDecay{}),
+		"WithCrossoverSchedule": WithCrossoverSchedule(LinearDecay{}),
+		"WithInitializer":       WithInitializer(&mockInitializer{}, 4),
+		"WithHallOfFame":        WithHallOfFame(3),
+	}
+	for name, opt := range unsupported {
+		err := NewNSGA2(newSchafferPopulation(4, 1), opt).Validate()
+		if err == nil || !strings.Contains(err.Error(), name+" is not supported by NSGA2") {
+			t.Errorf("Expected %s to be rejected, got %v", name, err)
+		}
+	}
+}
+
+// TestNonDominatedSort verifies vectors are partitioned into Pareto fronts
+func TestNonDominatedSort(t *testing.T) {
+	objectives := [][]float64{
+		{1, 1}, // 0: dominated by 2 and 3
+		{3, 0}, // 1: front 0
+		{2, 2}, // 2: front 0
+		{1, 2}, // 3: dominated by 2
+		{0, 3}, // 4: front 0
+		{0, 0}, // 5: dominated by everything else
+	}
+	want := [][]int{{1, 2, 4}, {3}, {0}, {5}}
+	if got := NonDominatedSort(objectives); !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected fronts %v, got %v", want, got)
+	}
+
+	if !Dominates([]float64{2, 2}, []float64{2, 1}) || Dominates([]float64{2, 2}, []float64{2, 2}) {
+		t.Error("Dominates must require strict improvement on at least one objective")
+	}
+}
+
+// TestCrowdingDistance verifies boundary points are infinite and interior
+// points sum their normalized neighbour gaps
+func TestCrowdingDistance(t *testing.T) {
+	objectives := [][]float64{{0, 4}, {1, 3}, {3, 1}, {4, 0}}
+	got := CrowdingDistance(objectives)
+	if !math.IsInf(got[0], 1) || !math.IsInf(got[3], 1) {
+		t.Errorf("Expected infinite boundary distances, got %v", got)
+	}
+	// Point 1: (3-0)/4 on each objective; point 2 likewise
+	if math.Abs(got[1]-1.5) > 1e-9 || math.Abs(got[2]-1.5) > 1e-9 {
+		t.Errorf("Expected interior distances 1.5, got %v", got)
+	}
+
+	if got := CrowdingDistance([][]float64{{1, 1}, {2, 2}}); !math.IsInf(got[0], 1) || !math.IsInf(got[1], 1) {
+		t.Errorf("Expected two-point fronts to be all boundary, got %v", got)
+	}
+}
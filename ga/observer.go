@@ -0,0 +1,139 @@
+package ga
+
+// EventKind identifies what happened in a run.
+type EventKind int
+
+const (
+	// RunStarted is sent once before the first generation of a run, and
+	// again when a run resumes from a checkpoint.
+	RunStarted EventKind = iota
+
+	// GenerationStarted is sent before a generation is evaluated.
+	GenerationStarted
+
+	// GenerationEnded is sent after a generation has been evaluated, at the
+	// same point as the progress and statistics callbacks.
+	GenerationEnded
+
+	// NewBest is sent when a generation produced a better individual than
+	// any before it.
+	NewBest
+
+	// Converged is sent when the WithConvergence rule fires, whether it ends
+	// the run or, with WithRestart, restarts it.
+	Converged
+
+	// Restarted is sent after WithRestart has replaced the population.
+	Restarted
+
+	// RunEnded is sent once when the run stops for any reason, including
+	// cancellation and errors.
+	RunEnded
+)
+
+// String returns a human-readable name for the event kind.
+func (k EventKind) String() string {
+	switch k {
+	case RunStarted:
+		return "run started"
+	case GenerationStarted:
+		return "generation started"
+	case GenerationEnded:
+		return "generation ended"
+	case NewBest:
+		return "new best"
+	case Converged:
+		return "converged"
+	case Restarted:
+		return "restarted"
+	case RunEnded:
+		return "run ended"
+	default:
+		return "unknown"
+	}
+}
+
+// Event describes something that happened in a run.
+type Event struct {
+	Kind EventKind
+
+	// Generation is the zero-based generation the event is about: the one
+	// about to be evaluated for RunStarted and GenerationStarted, and the
+	// last one evaluated otherwise (-1 if there is none).
+	Generation int
+
+	// Stats are the statistics of the last generation evaluated, zero before
+	// the first.
+	Stats GenerationStats
+
+	// Best is the best individual found so far and BestFitness its fitness.
+	// Best is nil before the first generation has been evaluated. For an
+	// Engine, use IndividualOf to get the typed individual.
+	Best        Chromosome
+	BestFitness float64
+
+	// Run holds the statistics of the run so far. For RunEnded its
+	// StopReason and Duration are final.
+	Run Stats
+
+	// Err is the error that ended the run, for RunEnded.
+	Err error
+}
+
+// Observer receives the events of a run. Observers are called synchronously
+// from the goroutine running the GA, in the order they were added, so they
+// should return quickly and must not modify the population.
+type Observer interface {
+	Observe(event Event)
+}
+
+// ObserverFunc adapts an ordinary function to the Observer interface.
+type ObserverFunc func(event Event)
+
+// Observe calls f(event).
+func (f ObserverFunc) Observe(event Event) {
+	f(event)
+}
+
+// WithObserver adds an observer to the run. Unlike WithProgressCallback, it
+// can be used several times, so logging, metrics and visualization can each
+// subscribe independently.
+//
+// Example:
+//
+//	ga.WithObserver(ga.ObserverFunc(func(e ga.Event) {
+//	    if e.Kind == ga.NewBest {
+//	        log.Printf("generation %d: new best %.4f", e.Generation, e.BestFitness)
+//	    }
+//	})),
+//	ga.WithObserver(metrics),
+func WithObserver(observer Observer) func(*GA) {
+	return func(ga *GA) {
+		ga.observers = append(ga.observers, observer)
+	}
+}
+
+// notify sends an event of the given kind about generation to every
+// observer.
+func (e *Engine[T]) notify(kind EventKind, state *runState[T], generation int, err error) {
+	if len(e.config.observers) == 0 {
+		return
+	}
+
+	event := Event{
+		Kind:        kind,
+		Generation:  generation,
+		BestFitness: state.bestFitness,
+		Run:         state.stats,
+		Err:         err,
+	}
+	if history := state.stats.History; len(history) > 0 {
+		event.Stats = history[len(history)-1]
+	}
+	if e.hasBest {
+		event.Best = e.chromosomeOf(e.best, state.bestFitness)
+	}
+	for _, observer := range e.config.observers {
+		observer.Observe(event)
+	}
+}
@@ -0,0 +1,181 @@
+package ga
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+// eventRecorder is an Observer that keeps every event it receives
+type eventRecorder struct {
+	events []Event
+}
+
+func (r *eventRecorder) Observe(event Event) {
+	r.events = append(r.events, event)
+}
+
+// kinds returns the kind and generation of each recorded event
+func (r *eventRecorder) kinds() []string {
+	kinds := make([]string, len(r.events))
+	for i, e := range r.events {
+		kinds[i] = fmt.Sprintf("%s %d", e.Kind, e.Generation)
+	}
+	return kinds
+}
+
+// count returns how many events of kind were recorded
+func (r *eventRecorder) count(kind EventKind) int {
+	n := 0
+	for _, e := range r.events {
+		if e.Kind == kind {
+			n++
+		}
+	}
+	return n
+}
+
+// TestObserverEventSequence verifies the order and contents of the events
+// of a short run
+func TestObserverEventSequence(t *testing.T) {
+	recorder := &eventRecorder{}
+	algorithm := New(
+		WithPopulation(mockPopulation(1, 2, 3)),
+		WithGenerations(3),
+		WithMutationRate(0),
+		WithObserver(recorder),
+	)
+	result, err := algorithm.RunContext(context.Background())
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+
+	want := []string{
+		"run started 0",
+		"generation started 0", "new best 0", "generation ended 0",
+		"generation started 1", "generation ended 1",
+		"generation started 2", "generation ended 2",
+		"run ended 2",
+	}
+	if got := recorder.kinds(); !reflect.DeepEqual(got, want) {
+		t.Fatalf("Expected events %v, got %v", want, got)
+	}
+
+	if first := recorder.events[0]; first.Best != nil {
+		t.Errorf("Expected no best individual before the first generation, got %v", first.Best)
+	}
+	for _, e := range recorder.events {
+		if e.Kind != GenerationEnded {
+			continue
+		}
+		if h := result.History[e.Generation]; e.Stats.Generation != h.Generation || e.Stats.Mean != h.Mean {
+			t.Errorf("Generation %d: expected the generation's statistics, got %+v", e.Generation, e.Stats)
+		}
+	}
+	last := recorder.events[len(recorder.events)-1]
+	if last.Best.Fitness() != 3 || last.BestFitness != 3 {
+		t.Errorf("Expected the best fitness 3 at the end, got %f", last.BestFitness)
+	}
+	if last.Run.StopReason != StopMaxGenerations || last.Run.Generations != 3 || last.Err != nil {
+		t.Errorf("Unexpected final run statistics %+v (error %v)", last.Run, last.Err)
+	}
+}
+
+// TestObserversSubscribeIndependently verifies every observer receives every
+// event, in the order the observers were added
+func TestObserversSubscribeIndependently(t *testing.T) {
+	var order []string
+	first, second := &eventRecorder{}, &eventRecorder{}
+	algorithm := New(
+		WithPopulation(mockPopulation(1, 2, 3)),
+		WithGenerations(2),
+		WithObserver(first),
+		WithObserver(ObserverFunc(func(e Event) {
+			order = append(order, "func")
+		})),
+		WithObserver(second),
+		WithObserver(ObserverFunc(func(e Event) {
+			if len(first.events) != len(second.events) || len(order) != len(first.events) {
+				t.Fatalf("Observers out of order at %s", e.Kind)
+			}
+		})),
+	)
+	if err := algorithm.Run(); err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	if len(first.events) == 0 || !reflect.DeepEqual(first.kinds(), second.kinds()) {
+		t.Errorf("Expected both recorders to see the same events, got %v and %v", first.kinds(), second.kinds())
+	}
+
+	if err := New(WithPopulation(mockPopulation(1)), WithObserver(nil)).Validate(); err == nil {
+		t.Error("Expected an error for a nil observer")
+	}
+}
+
+// TestObserverConvergenceAndRestarts verifies convergence and restarts are
+// reported as they happen
+func TestObserverConvergenceAndRestarts(t *testing.T) {
+	recorder := &eventRecorder{}
+	algorithm := New(
+		WithPopulation(mockPopulation(1, 2, 3)),
+		WithGenerations(100),
+		WithMutationRate(0),
+		WithConvergence(3, 0),
+		WithRestart(Restart{Initializer: &mockInitializer{}, MaxRestarts: 2}),
+		WithObserver(recorder),
+		WithRandomSeed(1),
+	)
+	result, err := algorithm.RunContext(context.Background())
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+
+	if got := recorder.count(Restarted); got != result.Restarts || got != 2 {
+		t.Errorf("Expected 2 restart events, got %d", got)
+	}
+	if got := recorder.count(Converged); got != 3 {
+		t.Errorf("Expected 3 convergence events, got %d", got)
+	}
+	for i, e := range recorder.events {
+		if e.Kind == Restarted && recorder.events[i-1].Kind != GenerationEnded {
+			t.Errorf("Expected a restart to follow the end of its generation, got %s", recorder.events[i-1].Kind)
+		}
+	}
+	if last := recorder.events[len(recorder.events)-1]; last.Kind != RunEnded || last.Run.StopReason != StopConverged {
+		t.Errorf("Expected the run to end converged, got %s (%s)", last.Kind, last.Run.StopReason)
+	}
+}
+
+// TestObserverRunEndedOnCancel verifies the final event carries the error
+// that ended a cancelled run
+func TestObserverRunEndedOnCancel(t *testing.T) {
+	recorder := &eventRecorder{}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	algorithm := New(
+		WithPopulation(newTSPPopulation(10, 8, 1)),
+		WithGenerations(50),
+		WithObserver(recorder),
+		WithObserver(ObserverFunc(func(e Event) {
+			if e.Kind == GenerationEnded && e.Generation == 4 {
+				cancel()
+			}
+		})),
+	)
+	if _, err := algorithm.RunContext(ctx); !errors.Is(err, context.Canceled) {
+		t.Fatalf("Expected the run to be cancelled, got %v", err)
+	}
+
+	if got := recorder.count(RunEnded); got != 1 {
+		t.Fatalf("Expected one run ended event, got %d", got)
+	}
+	last := recorder.events[len(recorder.events)-1]
+	if last.Kind != RunEnded || !errors.Is(last.Err, context.Canceled) || last.Run.StopReason != StopCancelled {
+		t.Errorf("Expected a cancelled run ended event, got %s with %v", last.Kind, last.Err)
+	}
+	if last.Generation != 4 {
+		t.Errorf("Expected the last generation evaluated to be 4, got %d", last.Generation)
+	}
+}
@@ -0,0 +1,207 @@
+package ga
+
+import (
+	"math"
+)
+
+// RateContext is what a RateSchedule sees when choosing the rate for the
+// generation about to be bred.
+type RateContext struct {
+	// Generation is the zero-based generation about to be bred and
+	// MaxGenerations the configured number of generations.
+	Generation     int
+	MaxGenerations int
+
+	// Base is the rate configured with WithMutationRate or
+	// WithCrossoverRate. Previous is the rate used for the previous
+	// generation, or Base before the first.
+	Base     float64
+	Previous float64
+
+	// Stats describes the current, evaluated generation.
+	Stats GenerationStats
+
+	// SuccessRate is the fraction of offspring bred in the previous
+	// generation that are fitter than the fitter of their parents, or NaN
+	// if there were none (for example in the first generation).
+	SuccessRate float64
+}
+
+// RateSchedule adapts the mutation or crossover rate during a run. The
+// engine consults it before breeding each generation; results are clamped
+// to [0, 1] and recorded in the generation's GenerationStats.
+type RateSchedule interface {
+	Rate(ctx RateContext) float64
+}
+
+// WithMutationSchedule makes the mutation rate follow schedule, starting
+// from the rate set with WithMutationRate.
+//
+// Example:
+//
+//	ga.WithMutationRate(0.2),
+//	ga.WithMutationSchedule(ga.LinearDecay{Final: 0.01}),
+func WithMutationSchedule(schedule RateSchedule) func(*GA) {
+	return func(ga *GA) {
+		ga.mutationSchedule = schedule
+	}
+}
+
+// WithCrossoverSchedule makes the crossover rate follow schedule, starting
+// from the rate set with WithCrossoverRate.
+func WithCrossoverSchedule(schedule RateSchedule) func(*GA) {
+	return func(ga *GA) {
+		ga.crossoverSchedule = schedule
+	}
+}
+
+// LinearDecay moves the rate linearly from the base rate in the first
+// generation to Final in the last.
+type LinearDecay struct {
+	Final float64
+}
+
+// Rate implements RateSchedule.
+func (d LinearDecay) Rate(ctx RateContext) float64 {
+	return ctx.Base + (d.Final-ctx.Base)*coolingProgress(ctx.Generation, ctx.MaxGenerations)
+}
+
+// ExponentialDecay moves the rate geometrically from the base rate in the
+// first generation to Final in the last, so it falls quickly at first and
+// slowly later. If the base rate or Final is not positive it decays
+// linearly instead.
+type ExponentialDecay struct {
+	Final float64
+}
+
+// Rate implements RateSchedule.
+func (d ExponentialDecay) Rate(ctx RateContext) float64 {
+	if ctx.Base <= 0 || d.Final <= 0 {
+		return LinearDecay(d).Rate(ctx)
+	}
+	return ctx.Base * math.Pow(d.Final/ctx.Base, coolingProgress(ctx.Generation, ctx.MaxGenerations))
+}
+
+// DiversityAdaptive raises the rate when population diversity collapses.
+// While GenerationStats.Diversity is at least MinDiversity the base rate is
+// used; below it the rate rises linearly toward MaxRate, which is reached
+// when every individual has the same fitness. Applied to the mutation rate
+// this re-injects variation into a converging population.
+type DiversityAdaptive struct {
+	// MinDiversity is the diversity below which the rate rises. Default is
+	// 0.3 if <= 0.
+	MinDiversity float64
+
+	// MaxRate is the rate at zero diversity. Default is 0.5 if <= 0; it is
+	// never below the base rate.
+	MaxRate float64
+}
+
+// Rate implements RateSchedule.
+func (d DiversityAdaptive) Rate(ctx RateContext) float64 {
+	minDiversity := d.MinDiversity
+	if minDiversity <= 0 {
+		minDiversity = 0.3
+	}
+	maxRate := d.MaxRate
+	if maxRate <= 0 {
+		maxRate = 0.5
+	}
+	maxRate = math.Max(maxRate, ctx.Base)
+
+	diversity := ctx.Stats.Diversity
+	if diversity >= minDiversity || math.IsNaN(diversity) {
+		return ctx.Base
+	}
+	return ctx.Base + (maxRate-ctx.Base)*(1-diversity/minDiversity)
+}
+
+// OneFifthRule adapts the rate with Rechenberg's 1/5th success rule: when
+// more than a fifth of the last generation's offspring improved on their
+// parents the search is making easy progress and the rate is raised to
+// explore more; when fewer did, it is lowered to exploit more.
+type OneFifthRule struct {
+	// Factor in (0, 1) scales the rate down after a poor generation; its
+	// inverse scales it up after a good one. Default is 0.85 if outside
+	// (0, 1).
+	Factor float64
+
+	// Min and Max bound the rate. Defaults are 0.001 and 1 if <= 0.
+	Min float64
+	Max float64
+}
+
+// Rate implements RateSchedule.
+func (r OneFifthRule) Rate(ctx RateContext) float64 {
+	factor := r.Factor
+	if factor <= 0 || factor >= 1 {
+		factor = 0.85
+	}
+	low, high := r.Min, r.Max
+	if low <= 0 {
+		low = 0.001
+	}
+	if high <= 0 {
+		high = 1
+	}
+
+	rate := ctx.Previous
+	switch {
+	case math.IsNaN(ctx.SuccessRate):
+		return rate
+	case ctx.SuccessRate > 0.2:
+		rate /= factor
+	case ctx.SuccessRate < 0.2:
+		rate *= factor
+	}
+	return math.Min(math.Max(rate, low), high)
+}
+
+// updateRates consults the rate schedules for the generation about to be
+// bred, stores the effective rates in state and records them in stats.
+func (e *Engine[T]) updateRates(state *runState[T], stats *GenerationStats, successRate float64) {
+	cfg := e.config
+	schedule := func(s RateSchedule, base, previous float64) float64 {
+		if s == nil {
+			return previous
+		}
+		rate := s.Rate(RateContext{
+			Generation:     stats.Generation,
+			MaxGenerations: cfg.Generations,
+			Base:           base,
+			Previous:       previous,
+			Stats:          *stats,
+			SuccessRate:    successRate,
+		})
+		if math.IsNaN(rate) {
+			return previous
+		}
+		return math.Min(math.Max(rate, 0), 1)
+	}
+
+	state.mutationRate = schedule(cfg.mutationSchedule, cfg.MutationRate, state.mutationRate)
+	state.crossoverRate = schedule(cfg.crossoverSchedule, cfg.CrossoverRate, state.crossoverRate)
+	stats.MutationRate = state.mutationRate
+	stats.CrossoverRate = state.crossoverRate
+}
+
+// successRate returns the fraction of newly bred members that are fitter
+// than the fitter of their parents, or NaN if there are none, and clears the
+// bred marks so each offspring is counted once.
+func successRate[T Individual[T]](population []*member[T]) float64 {
+	bred, improved := 0, 0
+	for _, m := range population {
+		if !m.bred {
+			continue
+		}
+		bred++
+		if m.fitness > m.parentFitness {
+			improved++
+		}
+		m.bred = false
+	}
+	if bred == 0 {
+		return math.NaN()
+	}
+	return float64(improved) / float64(bred)
+}
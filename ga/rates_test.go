@@ -0,0 +1,171 @@
+package ga
+
+import (
+	"context"
+	"errors"
+	"math"
+	"path/filepath"
+	"testing"
+)
+
+// TestDecaySchedules verifies linear and exponential decay hit their end
+// points
+func TestDecaySchedules(t *testing.T) {
+	at := func(generation int) RateContext {
+		return RateContext{Generation: generation, MaxGenerations: 11, Base: 0.4}
+	}
+	tests := []struct {
+		name     string
+		schedule RateSchedule
+		ctx      RateContext
+		want     float64
+	}{
+		{"linear start", LinearDecay{Final: 0}, at(0), 0.4},
+		{"linear middle", LinearDecay{Final: 0}, at(5), 0.2},
+		{"linear end", LinearDecay{Final: 0}, at(10), 0},
+		{"exponential start", ExponentialDecay{Final: 0.004}, at(0), 0.4},
+		{"exponential middle", ExponentialDecay{Final: 0.004}, at(5), 0.04},
+		{"exponential end", ExponentialDecay{Final: 0.004}, at(10), 0.004},
+		{"exponential to zero is linear", ExponentialDecay{Final: 0}, at(5), 0.2},
+	}
+	for _, tt := range tests {
+		if got := tt.schedule.Rate(tt.ctx); math.Abs(got-tt.want) > 1e-9 {
+			t.Errorf("%s: expected %v, got %v", tt.name, tt.want, got)
+		}
+	}
+}
+
+// TestDiversityAdaptive verifies the rate rises only below the diversity
+// threshold
+func TestDiversityAdaptive(t *testing.T) {
+	schedule := DiversityAdaptive{MinDiversity: 0.4, MaxRate: 0.5}
+	tests := []struct {
+		diversity float64
+		want      float64
+	}{
+		{0.8, 0.1},
+		{0.4, 0.1},
+		{0.2, 0.3},
+		{0, 0.5},
+	}
+	for _, tt := range tests {
+		ctx := RateContext{Base: 0.1, Stats: GenerationStats{Diversity: tt.diversity}}
+		if got := schedule.Rate(ctx); math.Abs(got-tt.want) > 1e-9 {
+			t.Errorf("Diversity %v: expected %v, got %v", tt.diversity, tt.want, got)
+		}
+	}
+}
+
+// TestOneFifthRule verifies the rate follows the success ratio
+func TestOneFifthRule(t *testing.T) {
+	rule := OneFifthRule{Factor: 0.5, Min: 0.01, Max: 0.8}
+	tests := []struct {
+		name     string
+		previous float64
+		success  float64
+		want     float64
+	}{
+		{"successful generation", 0.2, 0.5, 0.4},
+		{"poor generation", 0.2, 0.1, 0.1},
+		{"exactly one fifth", 0.2, 0.2, 0.2},
+		{"no offspring yet", 0.2, math.NaN(), 0.2},
+		{"clamped high", 0.6, 0.9, 0.8},
+		{"clamped low", 0.015, 0, 0.01},
+	}
+	for _, tt := range tests {
+		ctx := RateContext{Previous: tt.previous, SuccessRate: tt.success}
+		if got := rule.Rate(ctx); math.Abs(got-tt.want) > 1e-9 {
+			t.Errorf("%s: expected %v, got %v", tt.name, tt.want, got)
+		}
+	}
+}
+
+// TestSuccessRate verifies only new offspring count, and only once
+func TestSuccessRate(t *testing.T) {
+	population := []*member[chromosomeIndividual]{
+		{fitness: 5, bred: true, parentFitness: 4},
+		{fitness: 3, bred: true, parentFitness: 4},
+		{fitness: 9},
+	}
+	if got := successRate(population); got != 0.5 {
+		t.Errorf("Expected success rate 0.5, got %v", got)
+	}
+	if got := successRate(population); !math.IsNaN(got) {
+		t.Errorf("Expected offspring to be counted once, got %v", got)
+	}
+}
+
+// TestRateScheduleRecordedInHistory verifies the engine applies schedules
+// every generation and records the effective rates
+func TestRateScheduleRecordedInHistory(t *testing.T) {
+	algorithm := New(
+		WithPopulation(newTSPPopulation(10, 6, 1)),
+		WithGenerations(11),
+		WithMutationRate(0.5),
+		WithCrossoverRate(0.7),
+		WithMutationSchedule(LinearDecay{Final: 0}),
+		WithRandomSeed(1),
+	)
+	result, err := algorithm.RunContext(context.Background())
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	for i, generation := range result.History {
+		want := 0.5 - 0.05*float64(i)
+		if math.Abs(generation.MutationRate-want) > 1e-9 {
+			t.Errorf("Generation %d: expected mutation rate %v, got %v", i, want, generation.MutationRate)
+		}
+		if generation.CrossoverRate != 0.7 {
+			t.Errorf("Generation %d: expected fixed crossover rate 0.7, got %v", i, generation.CrossoverRate)
+		}
+	}
+}
+
+// TestRateScheduleResume verifies a resumed run with an adaptive schedule
+// matches an uninterrupted one
+func TestRateScheduleResume(t *testing.T) {
+	options := func() []func(*GA) {
+		return []func(*GA){
+			WithGenerations(20),
+			WithMutationRate(0.3),
+			WithMutationSchedule(OneFifthRule{}),
+			WithRandomSeed(8),
+		}
+	}
+
+	reference := New(append(options(), WithPopulation(newTSPPopulation(20, 8, 2)))...)
+	want, err := reference.RunContext(context.Background())
+	if err != nil {
+		t.Fatalf("Reference run failed: %v", err)
+	}
+
+	path := filepath.Join(t.TempDir(), "rates.ckpt")
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	interrupted := New(append(options(),
+		WithPopulation(newTSPPopulation(20, 8, 2)),
+		WithCheckpointer(&FileCheckpointer{Path: path}, 4),
+		WithProgressCallback(func(generation int, best Chromosome) {
+			if generation == 10 {
+				cancel()
+			}
+		}),
+	)...)
+	if _, err := interrupted.RunContext(ctx); !errors.Is(err, context.Canceled) {
+		t.Fatalf("Expected interrupted run to be cancelled, got %v", err)
+	}
+
+	got, err := New(options()...).Resume(path)
+	if err != nil {
+		t.Fatalf("Resume failed: %v", err)
+	}
+	for i := range want.History {
+		if got.History[i].MutationRate != want.History[i].MutationRate {
+			t.Fatalf("Generation %d: resumed mutation rate %v, uninterrupted %v",
+				i, got.History[i].MutationRate, want.History[i].MutationRate)
+		}
+	}
+	if routeNames(got.Best) != routeNames(want.Best) {
+		t.Errorf("Best route differs: resumed %s, uninterrupted %s", routeNames(got.Best), routeNames(want.Best))
+	}
+}
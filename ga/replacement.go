@@ -0,0 +1,191 @@
+package ga
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+)
+
+// ReplacementStrategy decides which individual an offspring replaces in
+// steady-state mode (see WithSteadyState).
+//
+// THREAD SAFETY: The rng parameter MUST be used for all random operations
+// instead of the global math/rand.
+type ReplacementStrategy interface {
+	// Replace returns the index in population that offspring replaces, or -1
+	// to discard offspring. The offspring has already been evaluated, and
+	// parents holds the indices of its parents that are still in the
+	// population.
+	Replace(population []Chromosome, offspring Chromosome, parents []int, rng *rand.Rand) int
+}
+
+// WithSteadyState switches the GA from generational to steady-state
+// replacement. Instead of rebuilding the whole population each generation,
+// every step breeds offspringPerStep offspring, evaluates them, and inserts
+// each one into the live population at the position chosen by strategy, so
+// good offspring can become parents immediately.
+//
+// A generation still counts len(Population) offspring, so Generations,
+// convergence, statistics and checkpoints keep their meaning. With elitism
+// enabled neither the current best individual nor the elites chosen at the
+// start of the generation are replaced by a worse offspring.
+//
+// Example:
+//
+//	ga.WithSteadyState(ga.WorstReplacement{}, 1)
+func WithSteadyState(strategy ReplacementStrategy, offspringPerStep int) func(*GA) {
+	return func(ga *GA) {
+		ga.replacement = strategy
+		ga.offspringPerStep = offspringPerStep
+	}
+}
+
+// WorstReplacement replaces the least fit individual in the population.
+//
+// All built-in strategies compare individuals with Fitter, so constrained
+// chromosomes follow Deb's feasibility rules.
+type WorstReplacement struct{}
+
+// Replace returns the index of the least fit individual.
+func (WorstReplacement) Replace(population []Chromosome, offspring Chromosome, parents []int, rng *rand.Rand) int {
+	worst := 0
+	for i, c := range population {
+		if Fitter(population[worst], c) {
+			worst = i
+		}
+	}
+	return worst
+}
+
+// RandomReplacement replaces an individual chosen uniformly at random.
+type RandomReplacement struct{}
+
+// Replace returns a random index.
+func (RandomReplacement) Replace(population []Chromosome, offspring Chromosome, parents []int, rng *rand.Rand) int {
+	return rng.Intn(len(population))
+}
+
+// ParentReplacement replaces the weaker of the offspring's parents, but only
+// if the offspring is fitter; otherwise the offspring is discarded.
+type ParentReplacement struct{}
+
+// Replace returns the index of the weaker parent, or -1.
+func (ParentReplacement) Replace(population []Chromosome, offspring Chromosome, parents []int, rng *rand.Rand) int {
+	weakest := -1
+	for _, p := range parents {
+		if weakest < 0 || Fitter(population[weakest], population[p]) {
+			weakest = p
+		}
+	}
+	if weakest < 0 || !Fitter(offspring, population[weakest]) {
+		return -1
+	}
+	return weakest
+}
+
+// LoserTournamentReplacement runs a tournament among TournamentSize randomly
+// chosen individuals and replaces the least fit of them. Larger tournaments
+// replace weaker individuals more reliably.
+type LoserTournamentReplacement struct {
+	// TournamentSize is the number of individuals competing in each tournament.
+	// Default is 2 if not specified or if <= 0.
+	TournamentSize int
+}
+
+// Replace returns the index of the tournament loser.
+func (r LoserTournamentReplacement) Replace(population []Chromosome, offspring Chromosome, parents []int, rng *rand.Rand) int {
+	tournamentSize := r.TournamentSize
+	if tournamentSize <= 0 {
+		tournamentSize = 2
+	}
+	if tournamentSize > len(population) {
+		tournamentSize = len(population)
+	}
+
+	loser := rng.Intn(len(population))
+	for j := 1; j < tournamentSize; j++ {
+		competitor := rng.Intn(len(population))
+		if Fitter(population[loser], population[competitor]) {
+			loser = competitor
+		}
+	}
+	return loser
+}
+
+// steadyState breeds one generation's worth of offspring in broods of
+// offspringPerStep and inserts them into a copy of population with the
+// replacement strategy. population must be sorted by descending fitness. It
+// returns ctx.Err() if ctx is cancelled, leaving population intact.
+func (e *Engine[T]) steadyState(ctx context.Context, population []*member[T], state *runState[T]) ([]*member[T], error) {
+	cfg := e.config
+	population = append([]*member[T](nil), population...)
+	selectable := make([]Chromosome, len(population))
+	index := make(map[*member[T]]int, len(population))
+	for j, m := range population {
+		selectable[j] = m
+		index[m] = j
+	}
+	e.prepareSelector(selectable)
+	best := 0
+	protected := make(map[*member[T]]bool)
+	for _, elite := range e.elites(population) {
+		protected[elite] = true
+	}
+
+	brood := make([]*member[T], 0, cfg.offspringPerStep)
+	lineage := make([][2]*member[T], 0, cfg.offspringPerStep)
+	for bred := 0; bred < len(population); bred += len(brood) {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+
+		// Breed a brood and score it in one batch, so parallel evaluation
+		// still has work to spread.
+		size := cfg.offspringPerStep
+		if remaining := len(population) - bred; size > remaining {
+			size = remaining
+		}
+		brood, lineage = brood[:0], lineage[:0]
+		for k := 0; k < size; k++ {
+			offspring, parent1, parent2, err := e.breed(selectable, state)
+			if err != nil {
+				return nil, err
+			}
+			brood = append(brood, offspring)
+			lineage = append(lineage, [2]*member[T]{parent1, parent2})
+		}
+		state.stats.Evaluations += e.evaluate(brood)
+		e.applyPenalty(brood, state.penaltyWeight)
+
+		for k, offspring := range brood {
+			parents := make([]int, 0, 2)
+			for _, parent := range lineage[k] {
+				if j, ok := index[parent]; ok {
+					parents = append(parents, j)
+				}
+			}
+
+			target := cfg.replacement.Replace(selectable, offspring, parents, cfg.rng)
+			if target < 0 {
+				continue
+			}
+			if target >= len(population) {
+				return nil, fmt.Errorf("replacement strategy returned index %d for a population of %d", target, len(population))
+			}
+			if cfg.Elitism && (target == best || protected[population[target]]) &&
+				Fitter(population[target], offspring) {
+				continue
+			}
+
+			delete(index, population[target])
+			population[target] = offspring
+			selectable[target] = offspring
+			index[offspring] = target
+			if Fitter(offspring, population[best]) {
+				best = target
+			}
+		}
+		e.prepareSelector(selectable)
+	}
+	return population, nil
+}
@@ -0,0 +1,120 @@
+package ga
+
+import (
+	"context"
+	"math/rand"
+	"testing"
+)
+
+// TestReplacementStrategies verifies each built-in strategy picks the
+// expected individual
+func TestReplacementStrategies(t *testing.T) {
+	population := mockPopulation(5, 1, 9, 3)
+	offspring := &MockChromosome{fitness: 4}
+	rng := rand.New(rand.NewSource(1))
+
+	if got := (WorstReplacement{}).Replace(population, offspring, nil, rng); got != 1 {
+		t.Errorf("WorstReplacement: expected 1, got %d", got)
+	}
+	if got := (ParentReplacement{}).Replace(population, offspring, []int{0, 3}, rng); got != 3 {
+		t.Errorf("ParentReplacement: expected weaker parent 3, got %d", got)
+	}
+	if got := (ParentReplacement{}).Replace(population, offspring, []int{0, 2}, rng); got != -1 {
+		t.Errorf("ParentReplacement: expected offspring to be discarded, got %d", got)
+	}
+	if got := (ParentReplacement{}).Replace(population, offspring, nil, rng); got != -1 {
+		t.Errorf("ParentReplacement without parents: expected -1, got %d", got)
+	}
+	if got := (LoserTournamentReplacement{TournamentSize: 50}).Replace(population, offspring, nil, rng); got != 1 {
+		t.Errorf("LoserTournamentReplacement: expected 1 with a full tournament, got %d", got)
+	}
+	for i := 0; i < 100; i++ {
+		if got := (RandomReplacement{}).Replace(population, offspring, nil, rng); got < 0 || got >= len(population) {
+			t.Fatalf("RandomReplacement: index %d out of range", got)
+		}
+	}
+}
+
+// TestSteadyStateNeverLosesBest verifies replacing the worst individual
+// keeps the best fitness non-decreasing across generations
+func TestSteadyStateNeverLosesBest(t *testing.T) {
+	algorithm := New(
+		WithPopulation(newTSPPopulation(30, 10, 3)),
+		WithGenerations(30),
+		WithMutationRate(0.3),
+		WithElitism(false),
+		WithSteadyState(WorstReplacement{}, 1),
+		WithRandomSeed(5),
+	)
+	result, err := algorithm.RunContext(context.Background())
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+
+	for i := 1; i < len(result.History); i++ {
+		if result.History[i].Best < result.History[i-1].Best {
+			t.Fatalf("Best fitness dropped at generation %d: %v -> %v",
+				i, result.History[i-1].Best, result.History[i].Best)
+		}
+	}
+	if result.History[len(result.History)-1].Best <= result.History[0].Best {
+		t.Errorf("Expected steady-state run to improve on the initial population")
+	}
+}
+
+// TestSteadyStateElitismProtectsBest verifies elitism keeps random
+// replacement from overwriting the best individual with a worse one
+func TestSteadyStateElitismProtectsBest(t *testing.T) {
+	algorithm := New(
+		WithPopulation(newTSPPopulation(20, 8, 4)),
+		WithGenerations(20),
+		WithMutationRate(0.5),
+		WithSteadyState(RandomReplacement{}, 4),
+		WithRandomSeed(6),
+	)
+	result, err := algorithm.RunContext(context.Background())
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	for i := 1; i < len(result.History); i++ {
+		if result.History[i].Best < result.History[i-1].Best {
+			t.Fatalf("Best fitness dropped at generation %d", i)
+		}
+	}
+}
+
+// TestSteadyStateReproducibleWithSeed verifies seeded steady-state runs,
+// including parallel evaluation of broods, are reproducible
+func TestSteadyStateReproducibleWithSeed(t *testing.T) {
+	run := func() string {
+		algorithm := New(
+			WithPopulation(newTSPPopulation(24, 9, 2)),
+			WithGenerations(15),
+			WithMutationRate(0.2),
+			WithSteadyState(LoserTournamentReplacement{TournamentSize: 3}, 3),
+			WithParallelEvaluation(4),
+			WithRandomSeed(11),
+		)
+		result, err := algorithm.RunContext(context.Background())
+		if err != nil {
+			t.Fatalf("Run failed: %v", err)
+		}
+		return routeNames(result.Best)
+	}
+
+	if first, second := run(), run(); first != second {
+		t.Errorf("Seeded steady-state runs differ: %s vs %s", first, second)
+	}
+}
+
+// TestValidateOffspringPerStep verifies a steady-state brood must hold at
+// least one offspring
+func TestValidateOffspringPerStep(t *testing.T) {
+	algorithm := New(
+		WithPopulation(mockPopulation(1, 2, 3)),
+		WithSteadyState(WorstReplacement{}, 0),
+	)
+	if err := algorithm.Validate(); err == nil {
+		t.Error("Expected validation error for zero offspring per step")
+	}
+}
@@ -0,0 +1,146 @@
+package ga
+
+import (
+	"fmt"
+	"math"
+)
+
+// Restart configures restarts on stagnation. When the WithConvergence rule
+// fires, instead of stopping, the GA keeps its Keep best individuals and
+// replaces the rest of the population with fresh ones, then carries on
+// until Generations or another stop condition ends the run. The best
+// individual found is kept across restarts.
+//
+// Growing the population at each restart (IPOP) trades exploitation in the
+// early, small populations for exploration in the later, large ones.
+type Restart struct {
+	// Initializer creates the fresh individuals. If nil, they are clones of
+	// the kept individuals mutated Mutations times each. An Engine only
+	// supports a nil Initializer.
+	Initializer Initializer
+
+	// Keep is the number of best individuals that survive a restart.
+	// With WithHallOfFame they are the best entries of the hall of fame,
+	// so solutions lost since they were found come back. Default is 1 if
+	// <= 0.
+	Keep int
+
+	// Mutations is the number of mutations applied to each clone when
+	// there is no Initializer. Default is 10 if <= 0.
+	Mutations int
+
+	// PopulationGrowth multiplies the population size at every restart;
+	// 2 gives IPOP-style doubling. Default is 1 (constant size) if < 1.
+	// The size is capped at the recommended maximum population size.
+	PopulationGrowth float64
+
+	// MaxRestarts limits the number of restarts, after which convergence
+	// stops the run as usual. Zero means no limit.
+	MaxRestarts int
+}
+
+// withDefaults fills in the defaults for unset fields.
+func (r Restart) withDefaults() Restart {
+	if r.Keep <= 0 {
+		r.Keep = 1
+	}
+	if r.Mutations <= 0 {
+		r.Mutations = 10
+	}
+	if r.PopulationGrowth < 1 {
+		r.PopulationGrowth = 1
+	}
+	return r
+}
+
+// WithRestart makes the GA restart when WithConvergence detects stagnation
+// instead of stopping. See Restart.
+//
+// Example:
+//
+//	ga.WithConvergence(30, 0),
+//	ga.WithRestart(ga.Restart{Initializer: routes, Keep: 2, PopulationGrowth: 2}),
+func WithRestart(restart Restart) func(*GA) {
+	return func(ga *GA) {
+		r := restart.withDefaults()
+		ga.restart = &r
+	}
+}
+
+// canRestart reports whether the run may restart once more.
+func (e *Engine[T]) canRestart(state *runState[T]) bool {
+	restart := e.config.restart
+	return restart != nil && (restart.MaxRestarts == 0 || state.stats.Restarts < restart.MaxRestarts)
+}
+
+// restartPopulation builds the population that follows a restart from
+// population, which must be sorted best first: clones of the Keep
+// survivors followed by fresh ones, grown by PopulationGrowth.
+func (e *Engine[T]) restartPopulation(population []*member[T]) ([]*member[T], error) {
+	cfg := e.config
+	restart := cfg.restart
+
+	size := int(math.Round(float64(len(population)) * restart.PopulationGrowth))
+	if size > maxRecommendedPopulation {
+		size = maxRecommendedPopulation
+	}
+	keep := restart.Keep
+	if keep > len(population) {
+		keep = len(population)
+	}
+	if keep > size {
+		keep = size
+	}
+
+	survivors := e.survivors(population, keep)
+	next := make([]*member[T], 0, size)
+	for _, m := range survivors {
+		next = append(next, m.clone())
+	}
+
+	if restart.Initializer == nil {
+		for j := 0; len(next) < size; j++ {
+			fresh := survivors[j%len(survivors)].clone()
+			for k := 0; k < restart.Mutations; k++ {
+				fresh.mutate(cfg.rng)
+			}
+			next = append(next, fresh)
+		}
+		return next, nil
+	}
+
+	fresh, err := e.initialMembers(restart.Initializer, size-len(next))
+	if err != nil {
+		return nil, err
+	}
+	for i, m := range fresh {
+		if err := e.validateIndividual(len(next)+i, m.value); err != nil {
+			return nil, fmt.Errorf("restart initializer: %w", err)
+		}
+	}
+	next = append(next, fresh...)
+	return next, nil
+}
+
+// survivors returns the keep individuals that survive a restart: the best
+// entries of the hall of fame, if there is one, topped up with the best
+// members of population, which must be sorted best first, that it does not
+// hold.
+func (e *Engine[T]) survivors(population []*member[T], keep int) []*member[T] {
+	survivors := make([]*member[T], 0, keep)
+	for _, m := range e.hallOfFame {
+		if len(survivors) == keep {
+			return survivors
+		}
+		survivors = append(survivors, m)
+	}
+	for _, m := range population {
+		if len(survivors) == keep {
+			break
+		}
+		if !e.inHallOfFame(m) {
+			survivors = append(survivors, m)
+		}
+	}
+	return survivors
+}
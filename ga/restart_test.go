@@ -0,0 +1,240 @@
+package ga
+
+import (
+	"context"
+	"errors"
+	"math"
+	"math/rand"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// mockInitializer creates MockChromosomes with random fitness below 1 and
+// counts how many it has created.
+type mockInitializer struct {
+	created int
+}
+
+func (m *mockInitializer) Initialize(size int, rng *rand.Rand) []Chromosome {
+	m.created += size
+	population := make([]Chromosome, size)
+	for i := range population {
+		population[i] = &MockChromosome{fitness: rng.Float64()}
+	}
+	return population
+}
+
+// TestRestartGrowsPopulation verifies stagnation restarts the run from the
+// kept individuals and fresh ones, growing the population each time
+func TestRestartGrowsPopulation(t *testing.T) {
+	initializer := &mockInitializer{}
+	algorithm := New(
+		WithPopulation(mockPopulation(1, 2, 3)),
+		WithGenerations(100),
+		WithMutationRate(0),
+		WithConvergence(3, 0),
+		WithRestart(Restart{Initializer: initializer, PopulationGrowth: 2, MaxRestarts: 2}),
+		WithRandomSeed(1),
+	)
+	result, err := algorithm.RunContext(context.Background())
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+
+	if result.Restarts != 2 || result.StopReason != StopConverged {
+		t.Errorf("Expected 2 restarts and then convergence, got %d restarts and %s", result.Restarts, result.StopReason)
+	}
+	// 3 -> 6 -> 12, keeping one individual each time
+	if initializer.created != 5+11 {
+		t.Errorf("Expected 16 fresh individuals, got %d", initializer.created)
+	}
+	if len(algorithm.Population) != 12 {
+		t.Errorf("Expected the final population to have 12 individuals, got %d", len(algorithm.Population))
+	}
+	if algorithm.Best().Fitness() != 3 {
+		t.Errorf("Expected the best individual to survive restarts, got fitness %f", algorithm.Best().Fitness())
+	}
+}
+
+// TestRestartMutatesKeptIndividuals verifies restarts without an
+// initializer continue until the generation budget is spent
+func TestRestartMutatesKeptIndividuals(t *testing.T) {
+	algorithm := New(
+		WithPopulation(newTSPPopulation(20, 8, 1)),
+		WithGenerations(60),
+		WithConvergence(5, 0),
+		WithRestart(Restart{Keep: 2, Mutations: 4}),
+		WithRandomSeed(1),
+	)
+	result, err := algorithm.RunContext(context.Background())
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+
+	if result.Restarts == 0 {
+		t.Error("Expected at least one restart")
+	}
+	if result.StopReason != StopMaxGenerations || result.Generations != 60 {
+		t.Errorf("Expected the run to use all 60 generations, got %d (%s)", result.Generations, result.StopReason)
+	}
+	if len(algorithm.Population) != 20 {
+		t.Errorf("Expected the population size to stay 20, got %d", len(algorithm.Population))
+	}
+}
+
+// TestRestartKeepsHallOfFame verifies restarts keep the best entries of the
+// hall of fame, topped up from the population
+func TestRestartKeepsHallOfFame(t *testing.T) {
+	members := func(fitness ...float64) []*member[chromosomeIndividual] {
+		population := make([]*member[chromosomeIndividual], len(fitness))
+		for i, f := range fitness {
+			population[i] = &member[chromosomeIndividual]{
+				value:     chromosomeIndividual{&MockChromosome{fitness: f}},
+				fitness:   f,
+				evaluated: true,
+			}
+		}
+		return population
+	}
+	fitness := func(population []*member[chromosomeIndividual]) []float64 {
+		values := make([]float64, len(population))
+		for i, m := range population {
+			values[i] = m.fitness
+		}
+		return values
+	}
+
+	tests := []struct {
+		name   string
+		fame   []float64
+		shared bool // the hall of fame holds the population's best
+		want   []float64
+	}{
+		{"no hall of fame", nil, false, []float64{3, 2}},
+		{"full hall of fame", []float64{10, 9, 8}, false, []float64{10, 9}},
+		{"short hall of fame", []float64{10}, false, []float64{10, 3}},
+		{"best already in hall of fame", []float64{3}, true, []float64{3, 2}},
+	}
+	for _, tt := range tests {
+		engine := New(
+			WithPopulation(mockPopulation(3, 2, 1)),
+			WithConvergence(1, 0),
+			WithRestart(Restart{Keep: 2, Mutations: 1}),
+			WithHallOfFame(3),
+		).engine()
+		population := members(3, 2, 1)
+		engine.hallOfFame = members(tt.fame...)
+		if tt.shared {
+			engine.hallOfFame[0] = population[0]
+		}
+
+		next, err := engine.restartPopulation(population)
+		if err != nil {
+			t.Fatalf("%s: restart failed: %v", tt.name, err)
+		}
+		if len(next) != 3 {
+			t.Fatalf("%s: expected 3 individuals, got %d", tt.name, len(next))
+		}
+		if got := fitness(next[:2]); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: expected survivors %v, got %v", tt.name, tt.want, got)
+		}
+	}
+}
+
+// TestRestartResumesFromCheckpoint verifies a resumed run with restarts
+// ends like an uninterrupted one
+func TestRestartResumesFromCheckpoint(t *testing.T) {
+	options := func() []func(*GA) {
+		return []func(*GA){
+			WithGenerations(40),
+			WithConvergence(4, 0),
+			WithRestart(Restart{PopulationGrowth: 1.5}),
+			WithRandomSeed(7),
+		}
+	}
+
+	reference := New(append(options(), WithPopulation(newTSPPopulation(10, 8, 1)))...)
+	want, err := reference.RunContext(context.Background())
+	if err != nil {
+		t.Fatalf("Reference run failed: %v", err)
+	}
+	if want.Restarts == 0 {
+		t.Fatal("Expected the reference run to restart")
+	}
+
+	path := filepath.Join(t.TempDir(), "run.ckpt")
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	interrupted := New(append(options(),
+		WithPopulation(newTSPPopulation(10, 8, 1)),
+		WithCheckpointer(&FileCheckpointer{Path: path}, 3),
+		WithProgressCallback(func(generation int, best Chromosome) {
+			if generation == 25 {
+				cancel()
+			}
+		}),
+	)...)
+	if _, err := interrupted.RunContext(ctx); !errors.Is(err, context.Canceled) {
+		t.Fatalf("Expected interrupted run to be cancelled, got %v", err)
+	}
+
+	resumed := New(options()...)
+	got, err := resumed.Resume(path)
+	if err != nil {
+		t.Fatalf("Resume failed: %v", err)
+	}
+	if got.Restarts != want.Restarts || got.Evaluations != want.Evaluations {
+		t.Errorf("Expected %d restarts and %d evaluations, got %d and %d",
+			want.Restarts, want.Evaluations, got.Restarts, got.Evaluations)
+	}
+	if len(resumed.Population) != len(reference.Population) {
+		t.Fatalf("Expected a final population of %d, got %d", len(reference.Population), len(resumed.Population))
+	}
+	for i := range reference.Population {
+		if routeNames(resumed.Population[i]) != routeNames(reference.Population[i]) {
+			t.Fatalf("Final population differs at index %d", i)
+		}
+	}
+}
+
+// TestRestartErrors verifies misconfigured restarts are reported
+func TestRestartErrors(t *testing.T) {
+	err := New(WithPopulation(mockPopulation(1, 2)), WithRestart(Restart{})).Validate()
+	if err == nil || !strings.Contains(err.Error(), "WithConvergence") {
+		t.Errorf("Expected an error for restart without convergence, got %v", err)
+	}
+
+	short := InitializerFunc(func(size int, rng *rand.Rand) []Chromosome {
+		return mockPopulation(0)
+	})
+	err = New(
+		WithPopulation(mockPopulation(1, 2, 3)),
+		WithMutationRate(0),
+		WithConvergence(2, 0),
+		WithRestart(Restart{Initializer: short}),
+	).Run()
+	if err == nil || !strings.Contains(err.Error(), "initializer returned 1 individuals") {
+		t.Errorf("Expected an error for a short initializer, got %v", err)
+	}
+
+	// Fresh individuals must support the options the population was
+	// validated against
+	unsupported := map[string]func(*GA){
+		"per-gene mutation requires GeneMutator":                 WithMutationMode(PerGene),
+		"niching without a Distance function requires Distancer": WithSteadyState(DeterministicCrowding{}, 2),
+	}
+	for want, opt := range unsupported {
+		err = New(
+			WithPopulation(newTSPPopulation(6, 6, 1)),
+			WithGenerations(10),
+			WithConvergence(1, math.Inf(1)),
+			WithRestart(Restart{Initializer: &mockInitializer{}}),
+			opt,
+		).Run()
+		if err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("Expected %q for fresh MockChromosomes, got %v", want, err)
+		}
+	}
+}
@@ -0,0 +1,458 @@
+package ga
+
+import (
+	"math"
+	"math/rand"
+	"sort"
+)
+
+// PopulationAware is implemented by selectors that precompute data from the
+// population, such as cumulative selection weights, instead of recomputing
+// it on every Select call. The engine calls Prepare whenever the population
+// it passes to Select changes: once per generation, and after each brood is
+// inserted in steady-state mode.
+//
+// Selectors that implement PopulationAware keep per-run state, so a single
+// instance must not be shared by GAs that run concurrently.
+type PopulationAware interface {
+	// Prepare is called with the population that subsequent Select calls
+	// receive.
+	Prepare(population []Chromosome)
+}
+
+// GenerationAware is implemented by operators that adapt to the progress of
+// the run, such as selectors with an annealing schedule. Before breeding
+// each generation the engine calls SetGeneration on the selector and the
+// replacement strategy if they implement it.
+type GenerationAware interface {
+	// SetGeneration is called with the zero-based generation about to be
+	// bred and the configured maximum number of generations.
+	SetGeneration(generation, maxGenerations int)
+}
+
+// selectionWeights holds the cumulative selection weights of a population.
+// It is shared by the fitness-proportional selectors.
+type selectionWeights struct {
+	population []Chromosome
+	cumulative []float64
+}
+
+// prepare computes cumulative fitness-proportional weights for population.
+//
+// Fitness is used as the weight directly when no finite fitness is negative;
+// otherwise all fitness values are shifted so the lowest finite one has
+// weight zero. If any individual has +Inf fitness, only those individuals
+// can be selected. -Inf and NaN fitness always get weight zero.
+func (w *selectionWeights) prepare(population []Chromosome) {
+	minimum := 0.0
+	infinite := false
+	for _, c := range population {
+		f := c.Fitness()
+		switch {
+		case math.IsInf(f, 1):
+			infinite = true
+		case !math.IsInf(f, -1) && !math.IsNaN(f) && f < minimum:
+			minimum = f
+		}
+	}
+
+	weights := make([]float64, len(population))
+	for i, c := range population {
+		f := c.Fitness()
+		switch {
+		case infinite:
+			if math.IsInf(f, 1) {
+				weights[i] = 1
+			}
+		case !math.IsInf(f, -1) && !math.IsNaN(f):
+			weights[i] = f - minimum
+		}
+	}
+	w.set(population, weights)
+}
+
+// set stores the cumulative sum of weights, one per individual of
+// population. When every weight is zero, selection is uniform.
+func (w *selectionWeights) set(population []Chromosome, weights []float64) {
+	w.population = population
+	if cap(w.cumulative) < len(population) {
+		w.cumulative = make([]float64, len(population))
+	}
+	w.cumulative = w.cumulative[:len(population)]
+
+	total := 0.0
+	for i, weight := range weights {
+		total += weight
+		w.cumulative[i] = total
+	}
+
+	// Fall back to uniform selection when no individual has positive weight
+	if total == 0 || math.IsInf(total, 1) {
+		for i := range w.cumulative {
+			w.cumulative[i] = float64(i + 1)
+		}
+	}
+}
+
+// prepared reports whether the weights were computed for population.
+func (w *selectionWeights) prepared(population []Chromosome) bool {
+	return len(population) > 0 && len(w.population) == len(population) &&
+		&w.population[0] == &population[0]
+}
+
+// total returns the sum of all weights.
+func (w *selectionWeights) total() float64 {
+	return w.cumulative[len(w.cumulative)-1]
+}
+
+// pick returns the index of the individual whose weight interval contains
+// point, a value in [0, total).
+func (w *selectionWeights) pick(point float64) int {
+	i := sort.Search(len(w.cumulative), func(i int) bool {
+		return w.cumulative[i] > point
+	})
+	if i == len(w.cumulative) {
+		i--
+	}
+	return i
+}
+
+// RouletteSelector implements fitness-proportional (roulette-wheel)
+// selection: each individual is chosen with probability proportional to its
+// fitness. Negative fitness values are shifted to make every weight
+// non-negative, and individuals with +Inf fitness take precedence over all
+// others.
+//
+// Cumulative weights are computed once per generation in Prepare; a
+// RouletteSelector must therefore not be shared by concurrently running GAs.
+type RouletteSelector struct {
+	weights selectionWeights
+}
+
+// Prepare precomputes the cumulative weights of population.
+func (s *RouletteSelector) Prepare(population []Chromosome) {
+	s.weights.prepare(population)
+}
+
+// Select spins the wheel twice and returns the two chosen parents.
+//
+// THREAD SAFETY: Uses the provided rng parameter instead of global math/rand.
+func (s *RouletteSelector) Select(population []Chromosome, rng *rand.Rand) []Chromosome {
+	return selectWeighted(&s.weights, s.Prepare, population, rng)
+}
+
+// StochasticUniversalSampler implements stochastic universal sampling (SUS).
+// Like roulette-wheel selection it chooses individuals in proportion to
+// their fitness, but it draws a whole mating pool at once using equally
+// spaced pointers from a single random offset. Each individual then appears
+// in the pool within one of its expected number of copies, which gives much
+// lower sampling variance than repeated roulette spins.
+//
+// The mating pool holds one entry per individual, is shuffled, and is handed
+// out two parents per Select call; a new pool is drawn when it runs out or
+// the population changes. Fitness is weighted as in RouletteSelector.
+type StochasticUniversalSampler struct {
+	weights selectionWeights
+	pool    []int
+	next    int
+}
+
+// Prepare precomputes the cumulative weights of population and discards the
+// current mating pool.
+func (s *StochasticUniversalSampler) Prepare(population []Chromosome) {
+	s.weights.prepare(population)
+	s.pool = s.pool[:0]
+	s.next = 0
+}
+
+// Select returns the next two parents from the mating pool.
+//
+// THREAD SAFETY: Uses the provided rng parameter instead of global math/rand.
+func (s *StochasticUniversalSampler) Select(population []Chromosome, rng *rand.Rand) []Chromosome {
+	if len(population) == 0 {
+		return []Chromosome{}
+	}
+	if !s.weights.prepared(population) {
+		s.Prepare(population)
+	}
+	if s.next+2 > len(s.pool) {
+		s.sample(rng)
+	}
+
+	parents := []Chromosome{population[s.pool[s.next]], population[s.pool[s.next+1]]}
+	s.next += 2
+	return parents
+}
+
+// sample draws a new shuffled mating pool.
+func (s *StochasticUniversalSampler) sample(rng *rand.Rand) {
+	n := len(s.weights.cumulative)
+	if n < 2 {
+		n = 2
+	}
+	spacing := s.weights.total() / float64(n)
+	offset := rng.Float64() * spacing
+
+	s.pool = s.pool[:0]
+	for i := 0; i < n; i++ {
+		s.pool = append(s.pool, s.weights.pick(offset+float64(i)*spacing))
+	}
+	rng.Shuffle(len(s.pool), func(a, b int) {
+		s.pool[a], s.pool[b] = s.pool[b], s.pool[a]
+	})
+	s.next = 0
+}
+
+// rankOrder returns the indices of population from fittest to least fit.
+// NaN fitness ranks last. A population that is already sorted, as it is
+// during a generational run, is detected in a single pass.
+func rankOrder(population []Chromosome) []int {
+	fitter := func(a, b float64) bool {
+		return a > b || (!math.IsNaN(a) && math.IsNaN(b))
+	}
+
+	order := make([]int, len(population))
+	sorted := true
+	for i := range order {
+		order[i] = i
+		if i > 0 && fitter(population[i].Fitness(), population[i-1].Fitness()) {
+			sorted = false
+		}
+	}
+	if !sorted {
+		sort.SliceStable(order, func(a, b int) bool {
+			return fitter(population[order[a]].Fitness(), population[order[b]].Fitness())
+		})
+	}
+	return order
+}
+
+// LinearRankSelector implements linear ranking selection. Individuals are
+// chosen with a probability that depends only on their rank, falling
+// linearly from the fittest to the least fit, so selection is unaffected by
+// the scale of the fitness values.
+//
+// With N individuals, the individual of rank r (0 = least fit) is chosen
+// with probability (2-s)/N + 2r(s-1)/(N(N-1)), where s is SelectionPressure:
+// the expected number of offspring of the fittest individual.
+type LinearRankSelector struct {
+	// SelectionPressure ranges from 1 (uniform selection) to 2 (the least
+	// fit individual is never chosen). Default is 1.5 if not specified or
+	// if <= 0; other values are clamped to [1, 2].
+	SelectionPressure float64
+
+	weights selectionWeights
+}
+
+// Prepare ranks population and precomputes the cumulative weights.
+func (s *LinearRankSelector) Prepare(population []Chromosome) {
+	pressure := s.SelectionPressure
+	switch {
+	case pressure <= 0:
+		pressure = 1.5
+	case pressure < 1:
+		pressure = 1
+	case pressure > 2:
+		pressure = 2
+	}
+
+	n := float64(len(population))
+	weights := make([]float64, len(population))
+	for position, i := range rankOrder(population) {
+		rank := n - 1 - float64(position)
+		weights[i] = (2 - pressure) / n
+		if n > 1 {
+			weights[i] += 2 * rank * (pressure - 1) / (n * (n - 1))
+		}
+	}
+	s.weights.set(population, weights)
+}
+
+// Select returns two parents chosen by rank.
+//
+// THREAD SAFETY: Uses the provided rng parameter instead of global math/rand.
+func (s *LinearRankSelector) Select(population []Chromosome, rng *rand.Rand) []Chromosome {
+	return selectWeighted(&s.weights, s.Prepare, population, rng)
+}
+
+// ExponentialRankSelector implements exponential ranking selection. The
+// fittest individual has weight 1, the next Base, then Base², and so on, so
+// selection pressure grows as Base shrinks while remaining independent of
+// the scale of the fitness values.
+type ExponentialRankSelector struct {
+	// Base is the weight ratio between consecutive ranks, in (0, 1]. A base
+	// of 1 is uniform selection. Default is 0.9 if not specified or if
+	// outside (0, 1].
+	Base float64
+
+	weights selectionWeights
+}
+
+// Prepare ranks population and precomputes the cumulative weights.
+func (s *ExponentialRankSelector) Prepare(population []Chromosome) {
+	base := s.Base
+	if base <= 0 || base > 1 {
+		base = 0.9
+	}
+
+	weights := make([]float64, len(population))
+	weight := 1.0
+	for _, i := range rankOrder(population) {
+		weights[i] = weight
+		weight *= base
+	}
+	s.weights.set(population, weights)
+}
+
+// Select returns two parents chosen by rank.
+//
+// THREAD SAFETY: Uses the provided rng parameter instead of global math/rand.
+func (s *ExponentialRankSelector) Select(population []Chromosome, rng *rand.Rand) []Chromosome {
+	return selectWeighted(&s.weights, s.Prepare, population, rng)
+}
+
+// selectWeighted draws two parents from population using weights, calling
+// prepare first if the weights belong to a different population.
+func selectWeighted(weights *selectionWeights, prepare func([]Chromosome), population []Chromosome, rng *rand.Rand) []Chromosome {
+	if len(population) == 0 {
+		return []Chromosome{}
+	}
+	if !weights.prepared(population) {
+		prepare(population)
+	}
+
+	parents := make([]Chromosome, 2)
+	for i := range parents {
+		parents[i] = population[weights.pick(rng.Float64()*weights.total())]
+	}
+	return parents
+}
+
+// TruncationSelector implements truncation selection: parents are drawn
+// uniformly from the fittest Fraction of the population and the rest never
+// breed.
+type TruncationSelector struct {
+	// Fraction of the population allowed to breed, in (0, 1]. Default is
+	// 0.5 if not specified or if outside (0, 1]. At least one individual is
+	// always kept.
+	Fraction float64
+
+	weights selectionWeights
+}
+
+// Prepare determines the individuals allowed to breed.
+func (s *TruncationSelector) Prepare(population []Chromosome) {
+	fraction := s.Fraction
+	if fraction <= 0 || fraction > 1 {
+		fraction = 0.5
+	}
+	keep := int(math.Ceil(fraction * float64(len(population))))
+
+	weights := make([]float64, len(population))
+	for _, i := range rankOrder(population)[:keep] {
+		weights[i] = 1
+	}
+	s.weights.set(population, weights)
+}
+
+// Select returns two parents drawn from the top of the population.
+//
+// THREAD SAFETY: Uses the provided rng parameter instead of global math/rand.
+func (s *TruncationSelector) Select(population []Chromosome, rng *rand.Rand) []Chromosome {
+	return selectWeighted(&s.weights, s.Prepare, population, rng)
+}
+
+// TemperatureSchedule returns the Boltzmann selection temperature for a
+// zero-based generation out of maxGenerations.
+type TemperatureSchedule func(generation, maxGenerations int) float64
+
+// LinearCooling lowers the temperature linearly from initial in the first
+// generation to final in the last.
+func LinearCooling(initial, final float64) TemperatureSchedule {
+	return func(generation, maxGenerations int) float64 {
+		return initial + (final-initial)*coolingProgress(generation, maxGenerations)
+	}
+}
+
+// ExponentialCooling lowers the temperature geometrically from initial in the
+// first generation to final in the last. Both must be positive.
+func ExponentialCooling(initial, final float64) TemperatureSchedule {
+	return func(generation, maxGenerations int) float64 {
+		return initial * math.Pow(final/initial, coolingProgress(generation, maxGenerations))
+	}
+}
+
+// coolingProgress maps generation to [0, 1] over a run of maxGenerations.
+func coolingProgress(generation, maxGenerations int) float64 {
+	if maxGenerations <= 1 {
+		return 0
+	}
+	return math.Min(float64(generation)/float64(maxGenerations-1), 1)
+}
+
+// BoltzmannSelector implements Boltzmann selection: individuals are chosen
+// with probability proportional to exp(fitness/T). A high temperature T makes
+// selection nearly uniform and favours exploration; as the schedule lowers T
+// over the run, selection concentrates on the fittest individuals.
+//
+// The temperature is in fitness units, so choose a schedule that matches the
+// spread of your fitness values. Individuals with +Inf fitness take
+// precedence over all others, and -Inf and NaN fitness are never chosen
+// unless nothing else can be.
+type BoltzmannSelector struct {
+	// Schedule gives the temperature for each generation. A constant
+	// temperature of 1 is used if nil. A temperature <= 0 selects only the
+	// fittest individuals.
+	Schedule TemperatureSchedule
+
+	generation     int
+	maxGenerations int
+	weights        selectionWeights
+}
+
+// SetGeneration records the generation used to compute the temperature.
+func (s *BoltzmannSelector) SetGeneration(generation, maxGenerations int) {
+	s.generation = generation
+	s.maxGenerations = maxGenerations
+}
+
+// Temperature returns the temperature for the current generation.
+func (s *BoltzmannSelector) Temperature() float64 {
+	if s.Schedule == nil {
+		return 1
+	}
+	return s.Schedule(s.generation, s.maxGenerations)
+}
+
+// Prepare precomputes the Boltzmann weights at the current temperature.
+func (s *BoltzmannSelector) Prepare(population []Chromosome) {
+	best := math.Inf(-1)
+	for _, c := range population {
+		if f := c.Fitness(); f > best {
+			best = f
+		}
+	}
+
+	temperature := s.Temperature()
+	weights := make([]float64, len(population))
+	for i, c := range population {
+		f := c.Fitness()
+		switch {
+		case math.IsNaN(f) || math.IsInf(f, -1):
+			// Never chosen unless every weight is zero
+		case f == best:
+			weights[i] = 1
+		case temperature > 0 && !math.IsInf(best, 1):
+			// Relative to the best fitness, so exp never overflows
+			weights[i] = math.Exp((f - best) / temperature)
+		}
+	}
+	s.weights.set(population, weights)
+}
+
+// Select returns two parents chosen with Boltzmann probabilities.
+//
+// THREAD SAFETY: Uses the provided rng parameter instead of global math/rand.
+func (s *BoltzmannSelector) Select(population []Chromosome, rng *rand.Rand) []Chromosome {
+	return selectWeighted(&s.weights, s.Prepare, population, rng)
+}
@@ -0,0 +1,277 @@
+package ga
+
+import (
+	"context"
+	"math"
+	"math/rand"
+	"testing"
+)
+
+// selectionCounts runs Select draws times and counts how often each
+// population index is chosen as a parent.
+func selectionCounts(selector Selector, population []Chromosome, draws int, seed int64) []int {
+	if p, ok := selector.(PopulationAware); ok {
+		p.Prepare(population)
+	}
+	rng := rand.New(rand.NewSource(seed))
+	counts := make([]int, len(population))
+	for i := 0; i < draws; i++ {
+		for _, parent := range selector.Select(population, rng) {
+			for j, c := range population {
+				if c == parent {
+					counts[j]++
+				}
+			}
+		}
+	}
+	return counts
+}
+
+// TestRouletteSelectorProportional verifies parents are chosen in
+// proportion to fitness
+func TestRouletteSelectorProportional(t *testing.T) {
+	counts := selectionCounts(&RouletteSelector{}, mockPopulation(1, 3), 5000, 1)
+	share := float64(counts[1]) / float64(counts[0]+counts[1])
+	if math.Abs(share-0.75) > 0.02 {
+		t.Errorf("Expected fitness 3 to be chosen ~75%% of the time, got %.3f", share)
+	}
+}
+
+// TestFitnessProportionalSpecialValues verifies negative, infinite and NaN
+// fitness values are weighted sensibly by both proportional selectors
+func TestFitnessProportionalSpecialValues(t *testing.T) {
+	selectors := map[string]func() Selector{
+		"roulette": func() Selector { return &RouletteSelector{} },
+		"sus":      func() Selector { return &StochasticUniversalSampler{} },
+	}
+	for name, newSelector := range selectors {
+		// Negative fitness is shifted, so the lowest never wins
+		counts := selectionCounts(newSelector(), mockPopulation(-5, -1, 0), 1000, 2)
+		if counts[0] != 0 || counts[1] == 0 || counts[2] == 0 {
+			t.Errorf("%s: unexpected counts for negative fitness: %v", name, counts)
+		}
+
+		// +Inf takes precedence over everything else
+		counts = selectionCounts(newSelector(), mockPopulation(1, math.Inf(1), math.NaN(), 2), 1000, 3)
+		if counts[1] != 2000 {
+			t.Errorf("%s: expected only the +Inf individual to be chosen, got %v", name, counts)
+		}
+
+		// Without any positive weight selection is uniform
+		counts = selectionCounts(newSelector(), mockPopulation(math.Inf(-1), math.NaN(), math.Inf(-1)), 1000, 4)
+		for j, count := range counts {
+			if count == 0 {
+				t.Errorf("%s: expected uniform selection, index %d never chosen: %v", name, j, counts)
+			}
+		}
+	}
+}
+
+// TestStochasticUniversalSamplerSpread verifies each mating pool holds every
+// individual within one of its expected number of copies
+func TestStochasticUniversalSamplerSpread(t *testing.T) {
+	population := mockPopulation(1, 1, 2, 4)
+	for seed := int64(0); seed < 20; seed++ {
+		// Two Select calls consume exactly one pool of four parents
+		counts := selectionCounts(&StochasticUniversalSampler{}, population, 2, seed)
+		if counts[2] != 1 || counts[3] != 2 || counts[0]+counts[1] != 1 {
+			t.Fatalf("Seed %d: expected pool counts [x y 1 2] with x+y=1, got %v", seed, counts)
+		}
+	}
+}
+
+// TestSelectorsWithoutPrepare verifies selectors still work when called
+// directly, without Prepare, and notice a different population
+func TestSelectorsWithoutPrepare(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	for _, selector := range []Selector{&RouletteSelector{}, &StochasticUniversalSampler{}} {
+		first := selector.Select(mockPopulation(0, 0, 5), rng)
+		if first[0].Fitness() != 5 || first[1].Fitness() != 5 {
+			t.Errorf("%T: expected the only positive individual, got %v and %v", selector, first[0].Fitness(), first[1].Fitness())
+		}
+		second := selector.Select(mockPopulation(7, 0, 0), rng)
+		if second[0].Fitness() != 7 || second[1].Fitness() != 7 {
+			t.Errorf("%T: expected selector to pick up the new population", selector)
+		}
+	}
+}
+
+// TestProportionalSelectorsInRun verifies the engine drives the selectors
+// reproducibly over a full run
+func TestProportionalSelectorsInRun(t *testing.T) {
+	for _, newSelector := range []func() Selector{
+		func() Selector { return &RouletteSelector{} },
+		func() Selector { return &StochasticUniversalSampler{} },
+	} {
+		run := func() string {
+			algorithm := New(
+				WithPopulation(newTSPPopulation(20, 8, 1)),
+				WithGenerations(20),
+				WithMutationRate(0.2),
+				WithSelector(newSelector()),
+				WithRandomSeed(9),
+			)
+			result, err := algorithm.RunContext(context.Background())
+			if err != nil {
+				t.Fatalf("Run failed: %v", err)
+			}
+			return routeNames(result.Best)
+		}
+		if first, second := run(), run(); first != second {
+			t.Errorf("%T: seeded runs differ: %s vs %s", newSelector(), first, second)
+		}
+	}
+}
+
+// TestLinearRankSelectorIgnoresScale verifies selection depends on rank
+// only, even when fitness spans many orders of magnitude
+func TestLinearRankSelectorIgnoresScale(t *testing.T) {
+	// Unsorted on purpose: ranks must not depend on population order
+	population := mockPopulation(1e6, 1e-6, 1)
+	counts := selectionCounts(&LinearRankSelector{SelectionPressure: 2}, population, 6000, 1)
+
+	// Pressure 2 over three ranks gives probabilities 2/3, 1/3 and 0
+	total := float64(counts[0] + counts[1] + counts[2])
+	if counts[1] != 0 {
+		t.Errorf("Expected the least fit individual never to be chosen, got %v", counts)
+	}
+	if share := float64(counts[0]) / total; math.Abs(share-2.0/3) > 0.02 {
+		t.Errorf("Expected the fittest individual ~2/3 of the time, got %.3f", share)
+	}
+}
+
+// TestLinearRankSelectorPressure verifies pressure 1 is uniform and invalid
+// pressures are clamped
+func TestLinearRankSelectorPressure(t *testing.T) {
+	counts := selectionCounts(&LinearRankSelector{SelectionPressure: 1}, mockPopulation(3, 2, 1), 6000, 2)
+	for j, count := range counts {
+		if share := float64(count) / 12000; math.Abs(share-1.0/3) > 0.02 {
+			t.Errorf("Expected uniform selection, index %d share %.3f", j, share)
+		}
+	}
+
+	counts = selectionCounts(&LinearRankSelector{SelectionPressure: 10}, mockPopulation(3, 2, 1), 1000, 3)
+	if counts[2] != 0 {
+		t.Errorf("Expected pressure above 2 to be clamped to 2, got %v", counts)
+	}
+}
+
+// TestExponentialRankSelector verifies weights fall geometrically with rank
+func TestExponentialRankSelector(t *testing.T) {
+	population := mockPopulation(-3, 50, 7)
+	counts := selectionCounts(&ExponentialRankSelector{Base: 0.5}, population, 7000, 4)
+
+	// Weights 1, 1/2 and 1/4 for ranks best to worst
+	want := map[int]float64{1: 4.0 / 7, 2: 2.0 / 7, 0: 1.0 / 7}
+	for j, expected := range want {
+		if share := float64(counts[j]) / 14000; math.Abs(share-expected) > 0.02 {
+			t.Errorf("Index %d: expected share %.3f, got %.3f", j, expected, share)
+		}
+	}
+}
+
+// TestTruncationSelector verifies only the top fraction breeds
+func TestTruncationSelector(t *testing.T) {
+	counts := selectionCounts(&TruncationSelector{Fraction: 0.4}, mockPopulation(1, 5, 2, 4, 3), 1000, 1)
+	if counts[0] != 0 || counts[2] != 0 || counts[4] != 0 {
+		t.Errorf("Expected only the two fittest to be chosen, got %v", counts)
+	}
+	if counts[1] == 0 || counts[3] == 0 {
+		t.Errorf("Expected both of the two fittest to be chosen, got %v", counts)
+	}
+
+	counts = selectionCounts(&TruncationSelector{Fraction: 0.01}, mockPopulation(1, 5, 2), 100, 2)
+	if counts[1] != 200 {
+		t.Errorf("Expected a tiny fraction to keep the single fittest, got %v", counts)
+	}
+}
+
+// TestBoltzmannSelectorTemperature verifies high temperatures select nearly
+// uniformly and low temperatures concentrate on the fittest
+func TestBoltzmannSelectorTemperature(t *testing.T) {
+	population := mockPopulation(1, 2, 3)
+
+	hot := &BoltzmannSelector{Schedule: LinearCooling(1000, 1000)}
+	counts := selectionCounts(hot, population, 3000, 1)
+	for j, count := range counts {
+		if share := float64(count) / 6000; math.Abs(share-1.0/3) > 0.03 {
+			t.Errorf("Hot: expected near-uniform selection, index %d share %.3f", j, share)
+		}
+	}
+
+	cold := &BoltzmannSelector{Schedule: LinearCooling(0.01, 0.01)}
+	counts = selectionCounts(cold, population, 1000, 2)
+	if counts[2] != 2000 {
+		t.Errorf("Cold: expected only the fittest to be chosen, got %v", counts)
+	}
+
+	frozen := &BoltzmannSelector{Schedule: LinearCooling(0, 0)}
+	counts = selectionCounts(frozen, mockPopulation(1, math.NaN(), 3, 3), 1000, 3)
+	if counts[0] != 0 || counts[1] != 0 || counts[2] == 0 || counts[3] == 0 {
+		t.Errorf("Zero temperature: expected only the tied fittest, got %v", counts)
+	}
+}
+
+// TestTemperatureSchedules verifies the cooling schedules hit their end points
+func TestTemperatureSchedules(t *testing.T) {
+	linear := LinearCooling(10, 0)
+	exponential := ExponentialCooling(10, 0.1)
+	tests := []struct {
+		name     string
+		schedule TemperatureSchedule
+		gen, max int
+		want     float64
+	}{
+		{"linear start", linear, 0, 11, 10},
+		{"linear middle", linear, 5, 11, 5},
+		{"linear end", linear, 10, 11, 0},
+		{"exponential start", exponential, 0, 11, 10},
+		{"exponential middle", exponential, 5, 11, 1},
+		{"exponential end", exponential, 10, 11, 0.1},
+		{"single generation", linear, 0, 1, 10},
+	}
+	for _, tt := range tests {
+		if got := tt.schedule(tt.gen, tt.max); math.Abs(got-tt.want) > 1e-9 {
+			t.Errorf("%s: expected %v, got %v", tt.name, tt.want, got)
+		}
+	}
+}
+
+// generationRecorder is a selector that records the generations it is told
+// about.
+type generationRecorder struct {
+	TournamentSelector
+	generations []int
+	max         int
+}
+
+func (r *generationRecorder) SetGeneration(generation, maxGenerations int) {
+	r.generations = append(r.generations, generation)
+	r.max = maxGenerations
+}
+
+// TestEngineCallsGenerationAware verifies the engine reports every bred
+// generation to a GenerationAware selector
+func TestEngineCallsGenerationAware(t *testing.T) {
+	recorder := &generationRecorder{}
+	algorithm := New(
+		WithPopulation(mockPopulation(1, 2, 3, 4)),
+		WithGenerations(5),
+		WithSelector(recorder),
+		WithRandomSeed(1),
+	)
+	if err := algorithm.Run(); err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+
+	want := []int{0, 1, 2, 3, 4}
+	if len(recorder.generations) != len(want) || recorder.max != 5 {
+		t.Fatalf("Expected generations %v of 5, got %v of %d", want, recorder.generations, recorder.max)
+	}
+	for i := range want {
+		if recorder.generations[i] != want[i] {
+			t.Errorf("Expected generations %v, got %v", want, recorder.generations)
+			break
+		}
+	}
+}
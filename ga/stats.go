@@ -0,0 +1,131 @@
+package ga
+
+import "math"
+
+// StopReason records why a run ended.
+type StopReason int
+
+const (
+	// StopNone means the run did not finish normally, e.g. it failed with
+	// an error other than cancellation.
+	StopNone StopReason = iota
+
+	// StopMaxGenerations means the configured number of generations ran.
+	StopMaxGenerations
+
+	// StopConverged means the WithConvergence plateau rule fired.
+	StopConverged
+
+	// StopTargetReached means the WithTargetFitness target was reached.
+	StopTargetReached
+
+	// StopCancelled means the context passed to RunContext was cancelled or
+	// its deadline expired.
+	StopCancelled
+
+	// StopTerminated means the WithTerminator criterion was met;
+	// Stats.Termination describes which.
+	StopTerminated
+)
+
+// String returns a human-readable name for the stop reason.
+func (r StopReason) String() string {
+	switch r {
+	case StopMaxGenerations:
+		return "max generations"
+	case StopConverged:
+		return "converged"
+	case StopTargetReached:
+		return "target reached"
+	case StopCancelled:
+		return "cancelled"
+	case StopTerminated:
+		return "terminated"
+	default:
+		return "none"
+	}
+}
+
+// GenerationStats summarizes the fitness distribution of one generation.
+// Mean and StdDev are computed over finite fitness values only, so a single
+// +Inf (e.g. a zero-length TSP route) does not turn them into NaN.
+type GenerationStats struct {
+	// Generation is the zero-based generation number.
+	Generation int
+
+	// Best, Mean and Worst describe the population's fitness.
+	Best  float64
+	Mean  float64
+	Worst float64
+
+	// StdDev is the population standard deviation of fitness.
+	StdDev float64
+
+	// Diversity is the fraction of distinct fitness values in the
+	// population, from 1/size (all identical) to 1 (all different). It is a
+	// cheap, genotype-agnostic indicator of diversity collapse.
+	Diversity float64
+
+	// UniqueRatio is the fraction of distinct genomes in the population.
+	// Genomes are compared with Equal when individuals implement Equaler
+	// and by fitness otherwise, in which case it equals Diversity. It is
+	// NaN without WithDiversityMetrics.
+	UniqueRatio float64
+
+	// MeanDistance is the mean Distancer distance between pairs of
+	// individuals, sampled in large populations, or NaN if individuals do
+	// not implement Distancer or WithDiversityMetrics is off.
+	MeanDistance float64
+
+	// FitnessEntropy is the normalized entropy of the fitness histogram,
+	// from 0 (all finite values equal) to 1 (spread evenly). A falling
+	// entropy signals that the population is crowding onto one level.
+	FitnessEntropy float64
+
+	// MutationRate and CrossoverRate are the rates used to breed the next
+	// generation from this one. They differ from the configured rates only
+	// when a RateSchedule is set.
+	MutationRate  float64
+	CrossoverRate float64
+}
+
+// generationStats summarizes the fitness of a population that has been
+// evaluated and sorted best first.
+func generationStats[T Individual[T]](generation int, population []*member[T]) GenerationStats {
+	stats := GenerationStats{
+		Generation: generation,
+		Best:       population[0].fitness,
+		Worst:      population[len(population)-1].fitness,
+	}
+
+	var sum float64
+	var finite int
+	distinct := make(map[float64]bool, len(population))
+	for _, m := range population {
+		if !math.IsInf(m.fitness, 0) && !math.IsNaN(m.fitness) {
+			sum += m.fitness
+			finite++
+		}
+		// Deb's rules may separate equal values, so count them in a set.
+		distinct[m.fitness] = true
+	}
+	stats.Diversity = float64(len(distinct)) / float64(len(population))
+
+	if finite == 0 {
+		stats.Mean = math.NaN()
+		stats.StdDev = math.NaN()
+		return stats
+	}
+	stats.Mean = sum / float64(finite)
+
+	var squares float64
+	for _, m := range population {
+		if !math.IsInf(m.fitness, 0) && !math.IsNaN(m.fitness) {
+			d := m.fitness - stats.Mean
+			squares += d * d
+		}
+	}
+	stats.StdDev = math.Sqrt(squares / float64(finite))
+
+	return stats
+}
@@ -0,0 +1,111 @@
+package ga
+
+import (
+	"context"
+	"math"
+	"testing"
+)
+
+func mockPopulation(fitness ...float64) []Chromosome {
+	population := make([]Chromosome, len(fitness))
+	for i, f := range fitness {
+		population[i] = &MockChromosome{fitness: f}
+	}
+	return population
+}
+
+// TestResultStopReasons verifies each way of ending a run is reported
+func TestResultStopReasons(t *testing.T) {
+	tests := []struct {
+		name    string
+		options []func(*GA)
+		cancel  bool
+		want    StopReason
+	}{
+		{"max generations", []func(*GA){WithGenerations(5)}, false, StopMaxGenerations},
+		{"converged", []func(*GA){WithGenerations(100), WithMutationRate(0), WithConvergence(3, 0)}, false, StopConverged},
+		{"target reached", []func(*GA){WithGenerations(100), WithTargetFitness(3)}, false, StopTargetReached},
+		{"cancelled", []func(*GA){WithGenerations(5)}, true, StopCancelled},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			options := append([]func(*GA){
+				WithPopulation(mockPopulation(1, 2, 3)),
+				WithRandomSeed(42),
+			}, tt.options...)
+
+			ctx, cancel := context.WithCancel(context.Background())
+			if tt.cancel {
+				cancel()
+			}
+			defer cancel()
+
+			result, _ := New(options...).RunContext(ctx)
+			if result.StopReason != tt.want {
+				t.Errorf("Expected stop reason %q, got %q", tt.want, result.StopReason)
+			}
+		})
+	}
+}
+
+// TestResultHistory verifies one history entry is recorded per generation
+func TestResultHistory(t *testing.T) {
+	ga := New(
+		WithPopulation(mockPopulation(1, 2, 3, 4)),
+		WithGenerations(8),
+		WithRandomSeed(42),
+	)
+
+	result, err := ga.RunContext(context.Background())
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+
+	if len(result.History) != result.Generations {
+		t.Fatalf("Expected %d history entries, got %d", result.Generations, len(result.History))
+	}
+	for i, h := range result.History {
+		if h.Generation != i {
+			t.Errorf("Entry %d has generation %d", i, h.Generation)
+		}
+		if h.Best < h.Mean || h.Mean < h.Worst {
+			t.Errorf("Entry %d: expected best >= mean >= worst, got %f, %f, %f", i, h.Best, h.Mean, h.Worst)
+		}
+	}
+	if result.Duration <= 0 {
+		t.Error("Expected a positive run duration")
+	}
+	if result.Evaluations < 4 {
+		t.Errorf("Expected at least the initial population to be evaluated, got %d", result.Evaluations)
+	}
+}
+
+// TestGenerationStats verifies the fitness summary of a sorted population
+func TestGenerationStats(t *testing.T) {
+	population := []*member[chromosomeIndividual]{
+		{fitness: math.Inf(1), evaluated: true},
+		{fitness: 4, evaluated: true},
+		{fitness: 2, evaluated: true},
+		{fitness: 2, evaluated: true},
+	}
+
+	stats := generationStats(3, population)
+
+	if stats.Generation != 3 {
+		t.Errorf("Expected generation 3, got %d", stats.Generation)
+	}
+	if !math.IsInf(stats.Best, 1) || stats.Worst != 2 {
+		t.Errorf("Expected best +Inf and worst 2, got %f and %f", stats.Best, stats.Worst)
+	}
+	// Mean and standard deviation ignore the infinite value.
+	if math.Abs(stats.Mean-8.0/3) > 1e-12 {
+		t.Errorf("Expected mean 8/3, got %f", stats.Mean)
+	}
+	if math.Abs(stats.StdDev-math.Sqrt(8.0/9)) > 1e-12 {
+		t.Errorf("Expected standard deviation sqrt(8/9), got %f", stats.StdDev)
+	}
+	if stats.Diversity != 0.75 {
+		t.Errorf("Expected diversity 0.75, got %f", stats.Diversity)
+	}
+}
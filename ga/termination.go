@@ -0,0 +1,226 @@
+package ga
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+)
+
+// TerminationContext is what a Terminator sees after each generation has
+// been evaluated.
+type TerminationContext struct {
+	// Generation is the zero-based generation that was just evaluated.
+	Generation int
+
+	// Stats describes that generation, and History every generation so far,
+	// ending with Stats.
+	Stats   GenerationStats
+	History []GenerationStats
+
+	// BestFitness and BestViolation describe the best individual found so
+	// far in the run.
+	BestFitness   float64
+	BestViolation float64
+
+	// Evaluations is the number of Fitness calls made so far and Elapsed the
+	// wall-clock time the run has taken, including time before a resume.
+	Evaluations int
+	Elapsed     time.Duration
+}
+
+// Terminator decides when a run should stop. After each generation the
+// engine asks the configured terminator, and if it returns true the run ends
+// with StopTerminated and Stats.Termination set to the returned reason.
+//
+// Terminators should base their decision on the context alone, as the
+// built-in ones do, so a run resumed from a checkpoint stops where the
+// original would have.
+type Terminator interface {
+	// Terminate reports whether the run should stop, and if so a short
+	// description of the criterion that was met.
+	Terminate(ctx TerminationContext) (stop bool, reason string)
+}
+
+// WithTerminator adds a termination criterion to the run. It works alongside
+// Generations, which stays the hard upper bound, and WithConvergence and
+// WithTargetFitness. Combine several criteria with Any and All.
+//
+// Example:
+//
+//	ga.WithTerminator(ga.Any(
+//	    ga.FitnessTarget{Target: 0.99},
+//	    ga.TimeBudget{Limit: time.Minute},
+//	))
+func WithTerminator(terminator Terminator) func(*GA) {
+	return func(ga *GA) {
+		ga.terminator = terminator
+	}
+}
+
+// FitnessTarget stops the run once a feasible individual with fitness of at
+// least Target has been found.
+type FitnessTarget struct {
+	Target float64
+}
+
+// Terminate implements Terminator.
+func (t FitnessTarget) Terminate(ctx TerminationContext) (bool, string) {
+	if ctx.BestFitness >= t.Target && ctx.BestViolation == 0 {
+		return true, fmt.Sprintf("fitness target %g reached", t.Target)
+	}
+	return false, ""
+}
+
+// TimeBudget stops the run once it has taken Limit of wall-clock time. The
+// check happens between generations, so the run may exceed Limit by up to
+// one generation; use RunContext with a deadline to stop mid-generation.
+type TimeBudget struct {
+	Limit time.Duration
+}
+
+// Terminate implements Terminator.
+func (t TimeBudget) Terminate(ctx TerminationContext) (bool, string) {
+	if ctx.Elapsed >= t.Limit {
+		return true, fmt.Sprintf("time budget %v spent", t.Limit)
+	}
+	return false, ""
+}
+
+// EvaluationBudget stops the run once Max fitness evaluations have been
+// made. The check happens between generations, so the run may exceed Max by
+// up to one generation's evaluations.
+type EvaluationBudget struct {
+	Max int
+}
+
+// Terminate implements Terminator.
+func (t EvaluationBudget) Terminate(ctx TerminationContext) (bool, string) {
+	if ctx.Evaluations >= t.Max {
+		return true, fmt.Sprintf("evaluation budget %d spent", t.Max)
+	}
+	return false, ""
+}
+
+// DiversityFloor stops the run once population diversity falls below Min,
+// which signals that the search has converged.
+type DiversityFloor struct {
+	// Min is the lowest acceptable diversity.
+	Min float64
+
+	// Measure extracts the diversity measure from the generation's
+	// statistics. GenerationStats.UniqueRatio is used if nil, which
+	// requires WithDiversityMetrics.
+	Measure func(stats GenerationStats) float64
+}
+
+// Terminate implements Terminator. A NaN measure never stops the run.
+func (t DiversityFloor) Terminate(ctx TerminationContext) (bool, string) {
+	diversity := ctx.Stats.UniqueRatio
+	if t.Measure != nil {
+		diversity = t.Measure(ctx.Stats)
+	}
+	if diversity < t.Min {
+		return true, fmt.Sprintf("diversity %g below floor %g", diversity, t.Min)
+	}
+	return false, ""
+}
+
+// Plateau stops the run once the best fitness found has not improved by more
+// than Threshold over the last Generations generations. Unlike
+// WithConvergence it compares the best fitness at either end of the window,
+// so many small improvements that add up to more than Threshold keep the run
+// going.
+type Plateau struct {
+	Generations int
+	Threshold   float64
+}
+
+// Terminate implements Terminator.
+func (t Plateau) Terminate(ctx TerminationContext) (bool, string) {
+	n := len(ctx.History)
+	if t.Generations < 1 || n <= t.Generations {
+		return false, ""
+	}
+
+	before, window := math.Inf(-1), math.Inf(-1)
+	for i, h := range ctx.History {
+		if i < n-t.Generations {
+			before = math.Max(before, h.Best)
+		} else {
+			window = math.Max(window, h.Best)
+		}
+	}
+	if !(window-before > t.Threshold) {
+		return true, fmt.Sprintf("no improvement above %g in %d generations", t.Threshold, t.Generations)
+	}
+	return false, ""
+}
+
+// Any returns a Terminator that stops the run as soon as one of terminators
+// does, reporting the first criterion met.
+func Any(terminators ...Terminator) Terminator {
+	return anyTerminator(terminators)
+}
+
+type anyTerminator []Terminator
+
+func (a anyTerminator) Terminate(ctx TerminationContext) (bool, string) {
+	for _, t := range a {
+		if stop, reason := t.Terminate(ctx); stop {
+			return true, reason
+		}
+	}
+	return false, ""
+}
+
+// All returns a Terminator that stops the run only when every one of
+// terminators does in the same generation, reporting all of their criteria.
+// All with no terminators never stops the run.
+func All(terminators ...Terminator) Terminator {
+	return allTerminator(terminators)
+}
+
+type allTerminator []Terminator
+
+func (a allTerminator) Terminate(ctx TerminationContext) (bool, string) {
+	if len(a) == 0 {
+		return false, ""
+	}
+	reasons := make([]string, 0, len(a))
+	for _, t := range a {
+		stop, reason := t.Terminate(ctx)
+		if !stop {
+			return false, ""
+		}
+		reasons = append(reasons, reason)
+	}
+	return true, strings.Join(reasons, " and ")
+}
+
+// readsUniqueRatio reports whether t includes a DiversityFloor that reads
+// GenerationStats.UniqueRatio.
+func readsUniqueRatio(t Terminator) bool {
+	switch t := t.(type) {
+	case DiversityFloor:
+		return t.Measure == nil
+	case *DiversityFloor:
+		return t != nil && t.Measure == nil
+	case anyTerminator:
+		return anyReadsUniqueRatio(t)
+	case allTerminator:
+		return anyReadsUniqueRatio(t)
+	}
+	return false
+}
+
+// anyReadsUniqueRatio reports whether any of terminators reads
+// GenerationStats.UniqueRatio.
+func anyReadsUniqueRatio(terminators []Terminator) bool {
+	for _, t := range terminators {
+		if readsUniqueRatio(t) {
+			return true
+		}
+	}
+	return false
+}
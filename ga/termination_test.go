@@ -0,0 +1,123 @@
+package ga
+
+import (
+	"context"
+	"math"
+	"testing"
+	"time"
+)
+
+// TestBuiltInTerminators verifies each criterion against hand-built contexts
+func TestBuiltInTerminators(t *testing.T) {
+	history := func(best ...float64) []GenerationStats {
+		h := make([]GenerationStats, len(best))
+		for i, b := range best {
+			h[i] = GenerationStats{Generation: i, Best: b}
+		}
+		return h
+	}
+
+	tests := []struct {
+		name       string
+		terminator Terminator
+		ctx        TerminationContext
+		want       bool
+	}{
+		{"target reached", FitnessTarget{Target: 5}, TerminationContext{BestFitness: 5}, true},
+		{"target missed", FitnessTarget{Target: 5}, TerminationContext{BestFitness: 4.9}, false},
+		{"target infeasible", FitnessTarget{Target: 5}, TerminationContext{BestFitness: 6, BestViolation: 1}, false},
+		{"time spent", TimeBudget{Limit: time.Second}, TerminationContext{Elapsed: time.Second}, true},
+		{"time left", TimeBudget{Limit: time.Second}, TerminationContext{Elapsed: time.Millisecond}, false},
+		{"evaluations spent", EvaluationBudget{Max: 100}, TerminationContext{Evaluations: 120}, true},
+		{"evaluations left", EvaluationBudget{Max: 100}, TerminationContext{Evaluations: 99}, false},
+		{"diversity low", DiversityFloor{Min: 0.2}, TerminationContext{Stats: GenerationStats{UniqueRatio: 0.1}}, true},
+		{"diversity ok", DiversityFloor{Min: 0.2}, TerminationContext{Stats: GenerationStats{UniqueRatio: 0.5}}, false},
+		{"diversity NaN", DiversityFloor{Min: 0.2, Measure: func(s GenerationStats) float64 { return s.MeanDistance }},
+			TerminationContext{Stats: GenerationStats{MeanDistance: math.NaN()}}, false},
+		{"plateau", Plateau{Generations: 3, Threshold: 0.5}, TerminationContext{History: history(1, 2, 2.2, 2.4, 2.3)}, true},
+		{"slow progress", Plateau{Generations: 3, Threshold: 0.5}, TerminationContext{History: history(1, 2, 2.2, 2.4, 2.6)}, false},
+		{"plateau too early", Plateau{Generations: 3}, TerminationContext{History: history(1, 1, 1)}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stop, reason := tt.terminator.Terminate(tt.ctx)
+			if stop != tt.want {
+				t.Errorf("Expected stop %v, got %v", tt.want, stop)
+			}
+			if stop && reason == "" {
+				t.Error("Expected a reason when stopping")
+			}
+		})
+	}
+}
+
+// TestAnyAll verifies the combinators and the reasons they report
+func TestAnyAll(t *testing.T) {
+	ctx := TerminationContext{BestFitness: 10, Evaluations: 50}
+	target := FitnessTarget{Target: 5}
+	budget := EvaluationBudget{Max: 100}
+
+	if stop, reason := Any(budget, target).Terminate(ctx); !stop || reason != "fitness target 5 reached" {
+		t.Errorf("Any: expected the target to stop the run, got %v %q", stop, reason)
+	}
+	if stop, _ := All(budget, target).Terminate(ctx); stop {
+		t.Error("All: expected the run to continue while the budget is left")
+	}
+
+	ctx.Evaluations = 100
+	if stop, reason := All(target, budget).Terminate(ctx); !stop ||
+		reason != "fitness target 5 reached and evaluation budget 100 spent" {
+		t.Errorf("All: expected both criteria, got %v %q", stop, reason)
+	}
+	if stop, _ := Any().Terminate(ctx); stop {
+		t.Error("Expected an empty Any to continue")
+	}
+	if stop, _ := All().Terminate(ctx); stop {
+		t.Error("Expected an empty All to continue")
+	}
+}
+
+// TestTerminatorStopsRun verifies the run reports the criterion that ended it
+func TestTerminatorStopsRun(t *testing.T) {
+	algorithm := New(
+		WithPopulation(newTSPPopulation(10, 8, 1)),
+		WithGenerations(1000),
+		WithRandomSeed(1),
+		WithTerminator(Any(
+			TimeBudget{Limit: time.Hour},
+			EvaluationBudget{Max: 50},
+		)),
+	)
+	result, err := algorithm.RunContext(context.Background())
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+
+	if result.StopReason != StopTerminated || result.StopReason.String() != "terminated" {
+		t.Errorf("Expected stop reason terminated, got %q", result.StopReason)
+	}
+	if result.Termination != "evaluation budget 50 spent" {
+		t.Errorf("Unexpected termination %q", result.Termination)
+	}
+	if result.Evaluations < 50 || result.Evaluations >= 60 {
+		t.Errorf("Expected to stop within one generation of 50 evaluations, got %d", result.Evaluations)
+	}
+}
+
+// TestPlateauStopsRun verifies a plateau ends a run that cannot improve
+func TestPlateauStopsRun(t *testing.T) {
+	algorithm := New(
+		WithPopulation(mockPopulation(1, 2, 3)),
+		WithGenerations(100),
+		WithMutationRate(0),
+		WithTerminator(Plateau{Generations: 5}),
+	)
+	result, err := algorithm.RunContext(context.Background())
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	if result.StopReason != StopTerminated || result.Generations != 6 {
+		t.Errorf("Expected to stop after 6 generations, got %d (%s)", result.Generations, result.StopReason)
+	}
+}
@@ -1,10 +1,15 @@
 package ga
 
 import (
+	"hash/fnv"
 	"math"
 	"math/rand"
 )
 
+func init() {
+	RegisterCodec("ga.TSPChromosome", &TSPChromosome{}, GobCodec[*TSPChromosome]{})
+}
+
 // City represents a city in the TSP problem with a name and 2D coordinates.
 type City struct {
 	Name string  // Name is the unique identifier for the city
@@ -13,6 +18,8 @@ type City struct {
 }
 
 // TSPChromosome is a chromosome for the TSP problem.
+// It implements RandomChromosome, so routes evolved by a seeded GA are
+// reproducible, and has a registered Codec, so runs can be checkpointed.
 type TSPChromosome struct {
 	Route []City
 }
@@ -36,7 +43,18 @@ func (c *TSPChromosome) Fitness() float64 {
 }
 
 // Crossover creates a new chromosome using Order Crossover (OX1).
+// It draws from the global math/rand source; the GA uses CrossoverRand.
 func (c *TSPChromosome) Crossover(other Chromosome) Chromosome {
+	return c.crossover(other, rand.Intn)
+}
+
+// CrossoverRand creates a new chromosome using Order Crossover (OX1), drawing
+// the crossover segment from rng.
+func (c *TSPChromosome) CrossoverRand(other Chromosome, rng *rand.Rand) Chromosome {
+	return c.crossover(other, rng.Intn)
+}
+
+func (c *TSPChromosome) crossover(other Chromosome, intn func(int) int) Chromosome {
 	parent1 := c.Route
 	parent2 := other.(*TSPChromosome).Route
 
@@ -48,8 +66,8 @@ func (c *TSPChromosome) Crossover(other Chromosome) Chromosome {
 	}
 
 	// Order Crossover (OX1)
-	start := rand.Intn(len(parent1))
-	end := rand.Intn(len(parent1))
+	start := intn(len(parent1))
+	end := intn(len(parent1))
 
 	if start > end {
 		start, end = end, start
@@ -85,22 +103,50 @@ func (c *TSPChromosome) Crossover(other Chromosome) Chromosome {
 }
 
 // Mutate randomly swaps two cities in the route.
+// It draws from the global math/rand source; the GA uses MutateRand.
 func (c *TSPChromosome) Mutate() {
+	c.mutate(rand.Intn)
+}
+
+// MutateRand randomly swaps two cities in the route, drawing them from rng.
+func (c *TSPChromosome) MutateRand(rng *rand.Rand) {
+	c.mutate(rng.Intn)
+}
+
+func (c *TSPChromosome) mutate(intn func(int) int) {
 	if len(c.Route) < 2 {
 		return
 	}
 
-	i := rand.Intn(len(c.Route))
-	j := rand.Intn(len(c.Route))
+	i := intn(len(c.Route))
+	j := intn(len(c.Route))
 
 	// Ensure we're swapping different cities
 	for i == j {
-		j = rand.Intn(len(c.Route))
+		j = intn(len(c.Route))
 	}
 
 	c.Route[i], c.Route[j] = c.Route[j], c.Route[i]
 }
 
+// Len returns the number of cities in the route. Together with MutateGene it
+// implements GeneMutator, so routes support per-gene mutation.
+func (c *TSPChromosome) Len() int {
+	return len(c.Route)
+}
+
+// MutateGene swaps the city at position i with another city drawn from rng.
+func (c *TSPChromosome) MutateGene(i int, rng *rand.Rand) {
+	if len(c.Route) < 2 {
+		return
+	}
+	j := rng.Intn(len(c.Route) - 1)
+	if j >= i {
+		j++
+	}
+	c.Route[i], c.Route[j] = c.Route[j], c.Route[i]
+}
+
 // Clone creates a deep copy of the chromosome.
 func (c *TSPChromosome) Clone() Chromosome {
 	route := make([]City, len(c.Route))
@@ -108,6 +154,83 @@ func (c *TSPChromosome) Clone() Chromosome {
 	return &TSPChromosome{Route: route}
 }
 
+// Equal reports whether other visits the same cities in the same order.
+func (c *TSPChromosome) Equal(other Chromosome) bool {
+	o, ok := other.(*TSPChromosome)
+	if !ok || len(o.Route) != len(c.Route) {
+		return false
+	}
+	for i := range c.Route {
+		if c.Route[i] != o.Route[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// Hash returns an FNV-1a hash of the city names in route order, consistent
+// with Equal.
+func (c *TSPChromosome) Hash() uint64 {
+	h := fnv.New64a()
+	for _, city := range c.Route {
+		h.Write([]byte(city.Name))
+		h.Write([]byte{0})
+	}
+	return h.Sum64()
+}
+
+// Distance returns the fraction of edges of the tour that other does not
+// share, ignoring direction: 0 for the same cycle, 1 for tours with no edge
+// in common. It implements Distancer, so GenerationStats can report the
+// mean distance between routes.
+func (c *TSPChromosome) Distance(other Chromosome) float64 {
+	o := other.(*TSPChromosome)
+	n := len(o.Route)
+	if len(c.Route) < 2 {
+		return 0
+	}
+
+	// Look up where each of c's cities is in other and compare its
+	// neighbours there.
+	positions := make(map[string]int, n)
+	for i, city := range o.Route {
+		positions[city.Name] = i
+	}
+	missing := 0
+	for i, city := range c.Route {
+		next := c.Route[(i+1)%len(c.Route)].Name
+		p, ok := positions[city.Name]
+		if !ok || o.Route[(p+1)%n].Name != next && o.Route[(p+n-1)%n].Name != next {
+			missing++
+		}
+	}
+	return float64(missing) / float64(len(c.Route))
+}
+
+// TSPRoutes returns a Build function for the permutation initializers that
+// creates the TSPChromosome visiting cities in the given order.
+//
+// Example:
+//
+//	ga.ShuffleInitializer{Length: len(cities), Build: ga.TSPRoutes(cities)}
+func TSPRoutes(cities []City) func(order []int) Chromosome {
+	return func(order []int) Chromosome {
+		route := make([]City, len(order))
+		for i, j := range order {
+			route[i] = cities[j]
+		}
+		return &TSPChromosome{Route: route}
+	}
+}
+
+// TSPDistance returns the Distance function for NearestNeighbourInitializer:
+// the Euclidean distance between cities i and j.
+func TSPDistance(cities []City) func(i, j int) float64 {
+	return func(i, j int) float64 {
+		return distance(cities[i], cities[j])
+	}
+}
+
 func distance(city1, city2 City) float64 {
 	dx := city1.X - city2.X
 	dy := city1.Y - city2.Y
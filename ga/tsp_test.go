@@ -2,6 +2,7 @@ package ga
 
 import (
 	"math"
+	"math/rand"
 	"os"
 	"sync"
 	"testing"
@@ -283,3 +284,72 @@ func TestConcurrentTSPOptimization(t *testing.T) {
 		t.Errorf("Concurrent TSP execution failed: %v", err)
 	}
 }
+
+// TestTSPSeededRunsAreReproducible verifies that TSP operators draw from the
+// GA's RNG, so two runs with the same seed evolve identical routes
+func TestTSPSeededRunsAreReproducible(t *testing.T) {
+	cities := make([]City, 12)
+	for i := range cities {
+		angle := float64(i) * 2 * math.Pi / float64(len(cities))
+		cities[i] = City{Name: string(rune('A' + i)), X: 10 * math.Cos(angle), Y: 10 * math.Sin(angle)}
+	}
+
+	run := func(seed int64) []City {
+		shuffle := rand.New(rand.NewSource(99))
+		population := make([]Chromosome, 30)
+		for i := range population {
+			route := make([]City, len(cities))
+			copy(route, cities)
+			shuffle.Shuffle(len(route), func(a, b int) { route[a], route[b] = route[b], route[a] })
+			population[i] = &TSPChromosome{Route: route}
+		}
+
+		ga := New(
+			WithPopulation(population),
+			WithGenerations(40),
+			WithMutationRate(0.2),
+			WithRandomSeed(seed),
+		)
+		if err := ga.Run(); err != nil {
+			t.Fatalf("Run failed: %v", err)
+		}
+		return ga.Best().(*TSPChromosome).Route
+	}
+
+	route1 := run(2024)
+	route2 := run(2024)
+
+	for i := range route1 {
+		if route1[i].Name != route2[i].Name {
+			t.Fatalf("Routes differ at position %d: %s vs %s", i, route1[i].Name, route2[i].Name)
+		}
+	}
+}
+
+// TestTSPRandOperatorsUseProvidedRNG verifies CrossoverRand and MutateRand
+// are deterministic for a given RNG seed
+func TestTSPRandOperatorsUseProvidedRNG(t *testing.T) {
+	cities := []City{
+		{Name: "A", X: 0, Y: 0},
+		{Name: "B", X: 1, Y: 1},
+		{Name: "C", X: 2, Y: 2},
+		{Name: "D", X: 3, Y: 3},
+		{Name: "E", X: 4, Y: 4},
+	}
+	parent1 := &TSPChromosome{Route: []City{cities[0], cities[1], cities[2], cities[3], cities[4]}}
+	parent2 := &TSPChromosome{Route: []City{cities[4], cities[2], cities[0], cities[3], cities[1]}}
+
+	operate := func() []City {
+		rng := rand.New(rand.NewSource(5))
+		child := parent1.CrossoverRand(parent2, rng).(*TSPChromosome)
+		child.MutateRand(rng)
+		return child.Route
+	}
+
+	child1, child2 := operate(), operate()
+	for i := range child1 {
+		if child1[i].Name != child2[i].Name {
+			t.Fatalf("Children differ at position %d: %s vs %s", i, child1[i].Name, child2[i].Name)
+		}
+	}
+}